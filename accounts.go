@@ -1,34 +1,36 @@
 package gohive
 
 import (
+	"encoding/json"
 	"fmt"
-	"strconv"
+	"strings"
 )
 
 // AccountData holds the output of the GetAccounts method.
 type AccountData struct {
-	Active                        map[string]interface{} `json:"active"`
-	Balance                       string                 `json:"balance"`
-	CanVote                       bool                   `json:"can_vote"`
-	CommentCount                  int                    `json:"comment_count"`
-	Created                       string                 `json:"created"`
-	CurationRewards               int                    `json:"curation_rewards"`
-	DelegatedVestingShares        string                 `json:"delegated_vesting_shares"`
-	DownVoteManaBar               map[string]interface{} `json:"down_vote_manabar"`
-	GuestBloggers                 []string               `json:"guest_bloggers"`
-	HbdBalance                    string                 `json:"sbd_balance"`
-	HbdSeconds                    string                 `json:"sbd_seconds"`
-	HbdSecondsLastUpdate          string                 `json:"sbd_seconds_last_update"`
-	HbdLastInterestPayment        string                 `json:"sbd_last_interest_payment"`
-	ID                            int                    `json:"id"`
-	JSONMetadata                  string                 `json:"json_metadata"`
-	LastAccountRecovery           string                 `json:"last_account_recovery"`
-	LastAccountUpdate             string                 `json:"last_account_update"`
-	LastOwnerUpdate               string                 `json:"last_owner_update"`
-	LastPost                      string                 `json:"last_post"`
-	LastRootPost                  string                 `json:"last_root_post"`
-	LastVoteTime                  string                 `json:"last_vote_time"`
-	LifetimeVoteCount             int                    `json:"lifetime_vote_count"`
+	Active                 map[string]interface{} `json:"active"`
+	Balance                string                 `json:"balance"`
+	CanVote                bool                   `json:"can_vote"`
+	CommentCount           int                    `json:"comment_count"`
+	Created                string                 `json:"created"`
+	CurationRewards        int                    `json:"curation_rewards"`
+	DelegatedVestingShares string                 `json:"delegated_vesting_shares"`
+	DownVoteManaBar        map[string]interface{} `json:"down_vote_manabar"`
+	GuestBloggers          []string               `json:"guest_bloggers"`
+	HbdBalance             string                 `json:"sbd_balance"`
+	HbdSeconds             string                 `json:"sbd_seconds"`
+	HbdSecondsLastUpdate   string                 `json:"sbd_seconds_last_update"`
+	HbdLastInterestPayment string                 `json:"sbd_last_interest_payment"`
+	ID                     int                    `json:"id"`
+	JSONMetadata           string                 `json:"json_metadata"`
+	LastAccountRecovery    string                 `json:"last_account_recovery"`
+	LastAccountUpdate      string                 `json:"last_account_update"`
+	LastOwnerUpdate        string                 `json:"last_owner_update"`
+	LastPost               string                 `json:"last_post"`
+	LastRootPost           string                 `json:"last_root_post"`
+	LastVoteTime           string                 `json:"last_vote_time"`
+	LifetimeVoteCount      int                    `json:"lifetime_vote_count"`
+	// Deprecated: nodes always return an empty list, use GetTradeHistory or GetOpenOrders.
 	MarketHistory                 []interface{}          `json:"market_history"`
 	MemoKey                       string                 `json:"memo_key"`
 	Mined                         bool                   `json:"mined"`
@@ -45,9 +47,9 @@ type AccountData struct {
 	PostingRewards                float64                `json:"posting_rewards"`
 	ProxiedVsfVotes               interface{}            `json:"proxied_vsf_votes"`
 	Proxy                         string                 `json:"proxy"`
-	ReceivedVestingShares         string                 `json:"recived_vesting_shares"`
-	RecoveryAccount               string                 `json:"recovery_Account"`
-	Reputation                    string                 `json:"reputation"`
+	ReceivedVestingShares         string                 `json:"received_vesting_shares"`
+	RecoveryAccount               string                 `json:"recovery_account"`
+	Reputation                    json.Number            `json:"reputation,omitempty"`
 	ResetAccount                  string                 `json:"reset_account"`
 	RewardHBDBalance              string                 `json:"reward_sbd_balance"`
 	RewardHiveBalance             string                 `json:"reward_steem_balance"`
@@ -60,13 +62,14 @@ type AccountData struct {
 	SavingsHbdLastInterestPayment string                 `json:"savings_sbd_last_interest_payment"`
 	TagsUsage                     []string               `json:"tags_usage"`
 	TransferHistory               []interface{}          `json:"transfer_history"`
-	ToWithdraw                    int                    `json:"to_withdraw"`
+	ToWithdraw                    json.Number            `json:"to_withdraw,omitempty"`
 	VestingBalance                string                 `json:"vesting_balance"`
 	VestingShares                 string                 `json:"vesting_shares"`
 	VestingWithdrawRate           string                 `json:"vesting_withdraw_rate"`
 	VoteHistory                   []interface{}          `json:"vote_history"`
+	VotingManabar                 Manabar                `json:"voting_manabar"`
 	VotingPower                   int                    `json:"voting_power"`
-	Withdrawn                     int                    `json:"withdrawn"`
+	Withdrawn                     json.Number            `json:"withdrawn,omitempty"`
 	WithdrawRoutes                int                    `json:"withdraw_routes"`
 	WitnessesVotedFor             int                    `json:"witnesses_vote_for"`
 	WitnessVotes                  []string               `json:"witness_votes"`
@@ -105,7 +108,15 @@ func (c *Client) GetAccountCount() (int64, error) {
 }
 
 // GetAccountHistory returns the history of an account.
+// Uses account_history_api.get_account_history when the Client uses AppbaseAPI.
 func (c *Client) GetAccountHistory(acc string, start, limit int) (interface{}, error) {
+	if c.API == AppbaseAPI {
+		arr, err := c.getAccountHistoryAppbase(acc, start, limit)
+		if err != nil {
+			return nil, err
+		}
+		return arr, nil
+	}
 
 	resp, err := c.getAccountData("get_account_history", acc, start, limit)
 	if err != nil {
@@ -122,46 +133,103 @@ func (c *Client) GetAccountHistory(acc string, start, limit int) (interface{}, e
 
 // AccountReputation is a struct for receiving data from GetAccountReputation()
 type AccountReputation struct {
-	Account    string `json:"account"`
-	Reputation string `json:"reputation"`
+	Account    string      `json:"account"`
+	Reputation json.Number `json:"reputation"`
 }
 
-// GetAccountReputation takes accounts name and returns a slice of type AccountReputation.
-// Returns `-1` when error is not nil.
-func (c *Client) GetAccountReputation(acc string) (int, error) {
+// Raw returns the raw on-chain reputation.
+func (r AccountReputation) Raw() (int64, error) {
+	return r.Reputation.Int64()
+}
 
-	resp, err := c.getAccountData("get_account_reputations", acc, 1)
+// Score returns the reputation on the familiar 25 based scale.
+func (r AccountReputation) Score() (float64, error) {
+	raw, err := ParseReputation(r.Reputation)
 	if err != nil {
-		return -1, err
+		return 0, err
 	}
+	return ReputationScoreBig(raw), nil
+}
 
-	data := []AccountReputation{}
-	if resp.GetObject(&data); err != nil {
+// GetAccountReputation takes an account name and returns its raw reputation.
+// Returns `-1` when error is not nil.
+// Uses reputation_api.get_account_reputations when the Client uses AppbaseAPI.
+func (c *Client) GetAccountReputation(acc string) (int64, error) {
+	data, err := c.getAccountReputations(acc, 1)
+	if err != nil {
 		return -1, err
 	}
 
-	num, err := strconv.Atoi(data[0].Reputation)
+	if len(data) == 0 || data[0].Account != acc {
+		return -1, fmt.Errorf("account %s not found", acc)
+	}
+
+	num, err := data[0].Raw()
 	if err != nil {
 		return -1, err
 	}
 	return num, nil
 }
 
+// GetAccountReputations returns the reputation of up to limit accounts whose name starts with prefix.
+func (c *Client) GetAccountReputations(prefix string, limit int) ([]AccountReputation, error) {
+	if limit < 1 || limit > maxListLimit {
+		return nil, fmt.Errorf("method GetAccountReputations needs a limit between 1 and %d", maxListLimit)
+	}
+
+	data, err := c.getAccountReputations(prefix, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	out := []AccountReputation{}
+	for _, r := range data {
+		if !strings.HasPrefix(r.Account, prefix) {
+			break
+		}
+		out = append(out, r)
+	}
+	return out, nil
+}
+
+// getAccountReputations returns up to limit reputations from lowerBound on.
+func (c *Client) getAccountReputations(lowerBound string, limit int) ([]AccountReputation, error) {
+	if c.API == AppbaseAPI {
+		return c.getAccountReputationsAppbase(lowerBound, limit)
+	}
+
+	resp, err := c.getAccountData("get_account_reputations", lowerBound, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	data := []AccountReputation{}
+	if err := resp.GetObject(&data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
 // GetAccounts updates a slice of account data for the accounts passed in.
 // At least one account is required.
-//Example:
-//c := a.NewChain()
+// Uses database_api.find_accounts when the Client uses AppbaseAPI.
+// Example:
 //
-//accData,err := c.GetAccounts("jrswab")
-//if err != nil {
-//	fmt.Println(err)
-//}
-//fmt.Println(data[0].Balance)
+//	c := gohive.NewClient()
+//	accData, err := c.GetAccounts("jrswab")
+//	if err != nil {
+//		fmt.Println(err)
+//	}
+//	fmt.Println((*accData)[0].Balance)
 func (c *Client) GetAccounts(acc ...string) (*[]AccountData, error) {
 	if len(acc) < 1 {
 		return nil, fmt.Errorf("method GetAccounts needs at least one account name")
 	}
 
+	if c.API == AppbaseAPI {
+		return c.FindAccounts(acc...)
+	}
+
 	resp, err := c.getAccountData("get_accounts", acc)
 	if err != nil {
 		return nil, err
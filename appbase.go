@@ -0,0 +1,225 @@
+package gohive
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// legacyAccountKeys maps appbase account fields to the condenser names AccountData decodes.
+var legacyAccountKeys = map[string]string{
+	"hbd_balance":                       "sbd_balance",
+	"hbd_seconds":                       "sbd_seconds",
+	"hbd_seconds_last_update":           "sbd_seconds_last_update",
+	"hbd_last_interest_payment":         "sbd_last_interest_payment",
+	"savings_hbd_balance":               "savings_sbd_balance",
+	"savings_hbd_seconds":               "savings_sbd_seconds",
+	"savings_hbd_seconds_last_update":   "savings_sbd_seconds_last_update",
+	"savings_hbd_last_interest_payment": "savings_sbd_last_interest_payment",
+	"reward_hbd_balance":                "reward_sbd_balance",
+	"reward_hive_balance":               "reward_steem_balance",
+	"reward_vesting_hive":               "reward_vesting_steem",
+	"downvote_manabar":                  "down_vote_manabar",
+	"next_vesting_withdrawal":           "next_vesting_withdraw",
+	"witnesses_voted_for":               "witnesses_vote_for",
+}
+
+// FindAccounts returns the data of the accounts passed in using database_api.find_accounts.
+// At least one account is required.
+func (c *Client) FindAccounts(acc ...string) (*[]AccountData, error) {
+	if len(acc) < 1 {
+		return nil, fmt.Errorf("method FindAccounts needs at least one account name")
+	}
+
+	resp, err := c.getAPIData("database_api.find_accounts", struct {
+		Accounts []string `json:"accounts"`
+	}{acc})
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Accounts []map[string]interface{} `json:"accounts"`
+	}
+	if err := decodeResult(resp, &result); err != nil {
+		return nil, err
+	}
+	return legacyAccounts(result.Accounts)
+}
+
+// getAccountHistoryAppbase calls account_history_api.get_account_history and
+// returns the entries in the condenser form.
+func (c *Client) getAccountHistoryAppbase(acc string, start, limit int) ([][]interface{}, error) {
+	params := struct {
+		Account string `json:"account"`
+		Start   uint64 `json:"start"`
+		Limit   int    `json:"limit"`
+	}{Account: acc, Start: math.MaxUint64, Limit: limit}
+	if start >= 0 {
+		params.Start = uint64(start)
+	}
+
+	resp, err := c.getAPIData("account_history_api.get_account_history", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		History [][]interface{} `json:"history"`
+	}
+	if err := resp.GetObject(&result); err != nil {
+		return nil, err
+	}
+
+	out := make([][]interface{}, 0, len(result.History))
+	for _, entry := range result.History {
+		if len(entry) != 2 {
+			return nil, fmt.Errorf("invalid account history entry: %v", entry)
+		}
+		op, ok := entry[1].(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid account history entry: %v", entry)
+		}
+		op = legacyValue(op).(map[string]interface{})
+		op["op"] = legacyOperation(op["op"])
+		out = append(out, []interface{}{entry[0], op})
+	}
+	return out, nil
+}
+
+// lookupAccountsAppbase returns up to limit account names from prefix on, using
+// database_api.list_accounts ordered by name.
+func (c *Client) lookupAccountsAppbase(prefix string, limit int) ([]string, error) {
+	resp, err := c.getAPIData("database_api.list_accounts", struct {
+		Start string       `json:"start"`
+		Limit int          `json:"limit"`
+		Order AccountOrder `json:"order"`
+	}{prefix, limit, AccountsByName})
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Accounts []struct {
+			Name string `json:"name"`
+		} `json:"accounts"`
+	}
+	if err := resp.GetObject(&result); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(result.Accounts))
+	for _, acc := range result.Accounts {
+		names = append(names, acc.Name)
+	}
+	return names, nil
+}
+
+// getAccountReputationsAppbase calls reputation_api.get_account_reputations.
+func (c *Client) getAccountReputationsAppbase(lowerBound string, limit int) ([]AccountReputation, error) {
+	resp, err := c.getAPIData("reputation_api.get_account_reputations", struct {
+		AccountLowerBound string `json:"account_lower_bound"`
+		Limit             int    `json:"limit"`
+	}{lowerBound, limit})
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Reputations []struct {
+			Name       string      `json:"name"`
+			Reputation json.Number `json:"reputation"`
+		} `json:"reputations"`
+	}
+	if err := resp.GetObject(&result); err != nil {
+		return nil, err
+	}
+
+	out := make([]AccountReputation, 0, len(result.Reputations))
+	for _, r := range result.Reputations {
+		out = append(out, AccountReputation{Account: r.Name, Reputation: r.Reputation})
+	}
+	return out, nil
+}
+
+// legacyAccounts converts appbase account objects into AccountData.
+func legacyAccounts(accounts []map[string]interface{}) (*[]AccountData, error) {
+	legacy := make([]interface{}, 0, len(accounts))
+	for _, acc := range accounts {
+		out := make(map[string]interface{}, len(acc))
+		for k, v := range acc {
+			if name, ok := legacyAccountKeys[k]; ok {
+				k = name
+			}
+			out[k] = legacyValue(v)
+		}
+		legacy = append(legacy, out)
+	}
+
+	b, err := json.Marshal(legacy)
+	if err != nil {
+		return nil, err
+	}
+	data := []AccountData{}
+	if err := json.Unmarshal(b, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// legacyValue replaces appbase NAI assets anywhere in v with legacy asset strings.
+func legacyValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		if s, ok := naiString(t); ok {
+			return s
+		}
+		out := make(map[string]interface{}, len(t))
+		for k, val := range t {
+			out[k] = legacyValue(val)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(t))
+		for i, val := range t {
+			out[i] = legacyValue(val)
+		}
+		return out
+	}
+	return v
+}
+
+// naiString returns the legacy string of m when m is an appbase NAI asset.
+func naiString(m map[string]interface{}) (string, bool) {
+	if len(m) != 3 {
+		return "", false
+	}
+	amount, ok := m["amount"].(string)
+	if !ok {
+		return "", false
+	}
+	nai, ok := m["nai"].(string)
+	if !ok {
+		return "", false
+	}
+	a, err := NAIAsset{Amount: amount, NAI: nai}.Asset()
+	if err != nil {
+		return "", false
+	}
+	return a.String(), true
+}
+
+// legacyOperation converts an appbase {"type": "vote_operation", "value": {...}}
+// operation into the condenser ["vote", {...}] form.
+func legacyOperation(op interface{}) interface{} {
+	m, ok := op.(map[string]interface{})
+	if !ok {
+		return op
+	}
+	name, ok := m["type"].(string)
+	if !ok {
+		return op
+	}
+	return []interface{}{strings.TrimSuffix(name, "_operation"), m["value"]}
+}
@@ -0,0 +1,156 @@
+package gohive
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Asset symbols used by Hive.
+const (
+	HIVE  = "HIVE"
+	HBD   = "HBD"
+	VESTS = "VESTS"
+)
+
+// nais maps appbase numeric asset identifiers to their symbol and precision.
+var nais = map[string]struct {
+	symbol    string
+	precision uint8
+}{
+	"@@000000021": {HIVE, 3},
+	"@@000000013": {HBD, 3},
+	"@@000000037": {VESTS, 6},
+}
+
+// Asset is an amount of HIVE, HBD or VESTS. Amount is in the smallest unit,
+// so 1.000 HIVE is Amount 1000 with Precision 3.
+// It decodes from both the legacy "1.000 HIVE" form and the appbase NAI object,
+// and encodes to the legacy form.
+type Asset struct {
+	Amount    int64
+	Precision uint8
+	Symbol    string
+}
+
+// NAIAsset is the appbase form of an Asset.
+type NAIAsset struct {
+	Amount    string `json:"amount"`
+	Precision uint8  `json:"precision"`
+	NAI       string `json:"nai"`
+}
+
+// ParseAsset parses a legacy asset string such as "1.000 HIVE".
+func ParseAsset(s string) (Asset, error) {
+	fields := strings.Fields(s)
+	if len(fields) != 2 {
+		return Asset{}, fmt.Errorf("invalid asset %q", s)
+	}
+
+	num := fields[0]
+	var precision uint8
+	if i := strings.IndexByte(num, '.'); i >= 0 {
+		precision = uint8(len(num) - i - 1)
+		num = num[:i] + num[i+1:]
+	}
+
+	amount, err := strconv.ParseInt(num, 10, 64)
+	if err != nil {
+		return Asset{}, fmt.Errorf("invalid asset %q: %v", s, err)
+	}
+	return Asset{Amount: amount, Precision: precision, Symbol: fields[1]}, nil
+}
+
+// NewAsset creates an Asset from a decimal amount, using the usual precision of symbol.
+func NewAsset(amount float64, symbol string) Asset {
+	precision := uint8(3)
+	if symbol == VESTS {
+		precision = 6
+	}
+	return Asset{
+		Amount:    int64(math.Round(amount * math.Pow10(int(precision)))),
+		Precision: precision,
+		Symbol:    symbol,
+	}
+}
+
+// String returns the legacy form of the asset, e.g. "1.000 HIVE".
+func (a Asset) String() string {
+	sign := ""
+	amount := a.Amount
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	digits := strconv.FormatInt(amount, 10)
+	if a.Precision == 0 {
+		return sign + digits + " " + a.Symbol
+	}
+	p := int(a.Precision)
+	if len(digits) <= p {
+		digits = strings.Repeat("0", p-len(digits)+1) + digits
+	}
+	return sign + digits[:len(digits)-p] + "." + digits[len(digits)-p:] + " " + a.Symbol
+}
+
+// Float64 returns the amount as a decimal number.
+func (a Asset) Float64() float64 {
+	return float64(a.Amount) / math.Pow10(int(a.Precision))
+}
+
+// NAI returns the appbase form of the asset.
+// The NAI field is empty for symbols without a known identifier.
+func (a Asset) NAI() NAIAsset {
+	out := NAIAsset{Amount: strconv.FormatInt(a.Amount, 10), Precision: a.Precision}
+	for nai, v := range nais {
+		if v.symbol == a.Symbol {
+			out.NAI = nai
+		}
+	}
+	return out
+}
+
+// Asset converts the appbase form into an Asset.
+func (n NAIAsset) Asset() (Asset, error) {
+	v, ok := nais[n.NAI]
+	if !ok {
+		return Asset{}, fmt.Errorf("unknown asset nai %q", n.NAI)
+	}
+	amount, err := strconv.ParseInt(n.Amount, 10, 64)
+	if err != nil {
+		return Asset{}, fmt.Errorf("invalid asset amount %q: %v", n.Amount, err)
+	}
+	return Asset{Amount: amount, Precision: v.precision, Symbol: v.symbol}, nil
+}
+
+// MarshalJSON encodes the asset in its legacy form.
+func (a Asset) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.String())
+}
+
+// UnmarshalJSON decodes an asset from its legacy or appbase form.
+func (a *Asset) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		out, err := ParseAsset(s)
+		if err != nil {
+			return err
+		}
+		*a = out
+		return nil
+	}
+
+	var n NAIAsset
+	if err := json.Unmarshal(b, &n); err != nil {
+		return fmt.Errorf("invalid asset %s", b)
+	}
+	out, err := n.Asset()
+	if err != nil {
+		return err
+	}
+	*a = out
+	return nil
+}
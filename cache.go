@@ -0,0 +1,335 @@
+package gohive
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	rpc "github.com/ybbus/jsonrpc"
+)
+
+// NoExpiry is the TTL used for responses that never change once irreversible.
+const NoExpiry time.Duration = -1
+
+// IrreversibleAge is how old a block or history entry has to be before it is
+// treated as irreversible. Hive blocks usually become irreversible within a minute.
+var IrreversibleAge = 2 * time.Minute
+
+// Cache stores RPC responses keyed by method and params.
+// Implementations must be safe for concurrent use.
+type Cache interface {
+	Get(key string) (*rpc.RPCResponse, bool)
+	Set(key string, resp *rpc.RPCResponse, ttl time.Duration)
+}
+
+// CacheRule returns how long a successful response may be cached.
+// A zero duration means the response is not cached.
+type CacheRule func(req *rpc.RPCRequest, resp *rpc.RPCResponse) time.Duration
+
+// FixedTTL returns a CacheRule which caches every response for ttl.
+func FixedTTL(ttl time.Duration) CacheRule {
+	return func(*rpc.RPCRequest, *rpc.RPCResponse) time.Duration {
+		return ttl
+	}
+}
+
+// DefaultCacheRules returns the TTL rules used by NewCachingCaller.
+// Chain config never changes, blocks and full account history pages are kept
+// forever once irreversible, and account and global state is cached for a few seconds.
+func DefaultCacheRules() map[string]CacheRule {
+	return map[string]CacheRule{
+		"get_config":                    FixedTTL(NoExpiry),
+		"get_block":                     irreversibleBlockTTL,
+		"get_account_history":           irreversibleHistoryTTL,
+		"get_accounts":                  FixedTTL(3 * time.Second),
+		"get_dynamic_global_properties": FixedTTL(3 * time.Second),
+
+		"database_api.get_config":                    FixedTTL(NoExpiry),
+		"database_api.find_accounts":                 FixedTTL(3 * time.Second),
+		"database_api.get_dynamic_global_properties": FixedTTL(3 * time.Second),
+		"block_api.get_block":                        appbaseBlockTTL,
+		"account_history_api.get_account_history":    appbaseHistoryTTL,
+	}
+}
+
+// CachingCaller is a Caller which answers repeated requests from a Cache.
+// Methods without a rule are always passed through to Next.
+// Example:
+// c := NewClient()
+// c.Client = NewCachingCaller(c.Client, NewLRUCache(1000))
+type CachingCaller struct {
+	Next  Caller
+	Cache Cache
+	Rules map[string]CacheRule
+}
+
+// NewCachingCaller wraps next with cache using DefaultCacheRules.
+func NewCachingCaller(next Caller, cache Cache) *CachingCaller {
+	return &CachingCaller{
+		Next:  next,
+		Cache: cache,
+		Rules: DefaultCacheRules(),
+	}
+}
+
+// CallRaw returns the cached response for req when there is one, otherwise it
+// calls Next and stores the response according to the rule for the method.
+func (c *CachingCaller) CallRaw(req *rpc.RPCRequest) (*rpc.RPCResponse, error) {
+	return c.CallRawContext(context.Background(), req)
+}
+
+// CallRawContext is CallRaw passing ctx on to Next.
+func (c *CachingCaller) CallRawContext(ctx context.Context, req *rpc.RPCRequest) (*rpc.RPCResponse, error) {
+	rule, ok := c.rule(req.Method)
+	if !ok {
+		return CallContext(ctx, c.Next, req)
+	}
+
+	key, err := CacheKey(req)
+	if err != nil {
+		return CallContext(ctx, c.Next, req)
+	}
+
+	if cached, ok := c.Cache.Get(key); ok {
+		resp := *cached
+		resp.ID = req.ID
+		return &resp, nil
+	}
+
+	resp, err := CallContext(ctx, c.Next, req)
+	if err != nil || resp == nil || resp.Error != nil {
+		return resp, err
+	}
+
+	if ttl := rule(req, resp); ttl != 0 {
+		c.Cache.Set(key, resp, ttl)
+	}
+	return resp, nil
+}
+
+// rule looks up the rule for method, ignoring a condenser_api namespace.
+func (c *CachingCaller) rule(method string) (CacheRule, bool) {
+	if r, ok := c.Rules[method]; ok {
+		return r, true
+	}
+	r, ok := c.Rules[strings.TrimPrefix(method, "condenser_api.")]
+	return r, ok
+}
+
+// CacheKey returns the key used to store the response to req.
+func CacheKey(req *rpc.RPCRequest) (string, error) {
+	params, err := json.Marshal(req.Params)
+	if err != nil {
+		return "", err
+	}
+	return req.Method + ":" + string(params), nil
+}
+
+// irreversibleBlockTTL caches a block forever once it is older than IrreversibleAge.
+func irreversibleBlockTTL(_ *rpc.RPCRequest, resp *rpc.RPCResponse) time.Duration {
+	var block struct {
+		Timestamp string `json:"timestamp"`
+	}
+	if err := resp.GetObject(&block); err != nil {
+		return 0
+	}
+	return blockTTL(block.Timestamp)
+}
+
+// appbaseBlockTTL is irreversibleBlockTTL for block_api.get_block, which wraps the block in an object.
+func appbaseBlockTTL(_ *rpc.RPCRequest, resp *rpc.RPCResponse) time.Duration {
+	var result struct {
+		Block *struct {
+			Timestamp string `json:"timestamp"`
+		} `json:"block"`
+	}
+	if err := resp.GetObject(&result); err != nil || result.Block == nil {
+		return 0
+	}
+	return blockTTL(result.Block.Timestamp)
+}
+
+// blockTTL caches a block produced at timestamp forever once it is irreversible.
+func blockTTL(timestamp string) time.Duration {
+	if !isIrreversible(timestamp) {
+		return 0
+	}
+	return NoExpiry
+}
+
+// irreversibleHistoryTTL caches a page of account history forever when it was
+// requested from a fixed start, the page reaches that start and its newest entry is irreversible.
+func irreversibleHistoryTTL(req *rpc.RPCRequest, resp *rpc.RPCResponse) time.Duration {
+	params, ok := req.Params.([]interface{})
+	if !ok || len(params) < 2 {
+		return 0
+	}
+	start, ok := paramInt(params[1])
+	if !ok {
+		return 0
+	}
+
+	var entries [][2]json.RawMessage
+	if err := resp.GetObject(&entries); err != nil {
+		return 0
+	}
+	return historyTTL(start, entries)
+}
+
+// appbaseHistoryTTL is irreversibleHistoryTTL for account_history_api.get_account_history,
+// which takes named params and wraps the entries in an object.
+func appbaseHistoryTTL(req *rpc.RPCRequest, resp *rpc.RPCResponse) time.Duration {
+	b, err := json.Marshal(req.Params)
+	if err != nil {
+		return 0
+	}
+	var params struct {
+		Start json.Number `json:"start"`
+	}
+	if err := json.Unmarshal(b, &params); err != nil {
+		return 0
+	}
+	// The newest entries are requested with the largest uint64, which does not fit an int64.
+	start, err := params.Start.Int64()
+	if err != nil {
+		return 0
+	}
+
+	var result struct {
+		History [][2]json.RawMessage `json:"history"`
+	}
+	if err := resp.GetObject(&result); err != nil {
+		return 0
+	}
+	return historyTTL(start, result.History)
+}
+
+// historyTTL caches a page of account history requested from start forever
+// when its newest entry is start and is irreversible.
+func historyTTL(start int64, entries [][2]json.RawMessage) time.Duration {
+	if start < 0 || len(entries) == 0 {
+		return 0
+	}
+
+	last := entries[len(entries)-1]
+	var index int64
+	if err := json.Unmarshal(last[0], &index); err != nil || index != start {
+		return 0
+	}
+
+	var op struct {
+		Timestamp string `json:"timestamp"`
+	}
+	if err := json.Unmarshal(last[1], &op); err != nil || !isIrreversible(op.Timestamp) {
+		return 0
+	}
+	return NoExpiry
+}
+
+// paramInt reads an integer request param which may have been built in Go or decoded from JSON.
+func paramInt(p interface{}) (int64, bool) {
+	switch v := p.(type) {
+	case int:
+		return int64(v), true
+	case int64:
+		return v, true
+	case float64:
+		return int64(v), true
+	case json.Number:
+		n, err := v.Int64()
+		return n, err == nil
+	}
+	return 0, false
+}
+
+// isIrreversible reports whether a Hive timestamp is older than IrreversibleAge.
+func isIrreversible(timestamp string) bool {
+	t, err := parseTime(timestamp)
+	if err != nil {
+		return false
+	}
+	return time.Since(t) > IrreversibleAge
+}
+
+// LRUCache is an in-memory Cache which evicts the least recently used
+// response once it is full.
+type LRUCache struct {
+	size    int
+	mu      sync.Mutex
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type lruEntry struct {
+	key     string
+	resp    *rpc.RPCResponse
+	expires time.Time
+}
+
+// NewLRUCache creates an LRUCache holding at most size responses.
+func NewLRUCache(size int) *LRUCache {
+	return &LRUCache{
+		size:    size,
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+// Get returns the response stored under key if it has not expired.
+func (l *LRUCache) Get(key string) (*rpc.RPCResponse, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	el, ok := l.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	e := el.Value.(*lruEntry)
+	if !e.expires.IsZero() && time.Now().After(e.expires) {
+		l.order.Remove(el)
+		delete(l.entries, key)
+		return nil, false
+	}
+
+	l.order.MoveToFront(el)
+	return e.resp, true
+}
+
+// Set stores resp under key for ttl. A negative ttl never expires.
+func (l *LRUCache) Set(key string, resp *rpc.RPCResponse, ttl time.Duration) {
+	if l.size < 1 {
+		return
+	}
+
+	var expires time.Time
+	if ttl > 0 {
+		expires = time.Now().Add(ttl)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if el, ok := l.entries[key]; ok {
+		el.Value = &lruEntry{key: key, resp: resp, expires: expires}
+		l.order.MoveToFront(el)
+		return
+	}
+
+	l.entries[key] = l.order.PushFront(&lruEntry{key: key, resp: resp, expires: expires})
+	for l.order.Len() > l.size {
+		oldest := l.order.Back()
+		l.order.Remove(oldest)
+		delete(l.entries, oldest.Value.(*lruEntry).key)
+	}
+}
+
+// Len returns the number of stored responses.
+func (l *LRUCache) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.order.Len()
+}
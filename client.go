@@ -1,6 +1,9 @@
 package gohive
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
 
 	rpc "github.com/ybbus/jsonrpc"
@@ -11,18 +14,48 @@ type Caller interface {
 	CallRaw(*rpc.RPCRequest) (*rpc.RPCResponse, error)
 }
 
+// ContextCaller is implemented by Callers which use the context of the call,
+// such as tracing wrappers. Callers that only implement Caller ignore the context.
+type ContextCaller interface {
+	CallRawContext(context.Context, *rpc.RPCRequest) (*rpc.RPCResponse, error)
+}
+
+// CallContext sends req through caller, passing ctx along when caller is a ContextCaller.
+func CallContext(ctx context.Context, caller Caller, req *rpc.RPCRequest) (*rpc.RPCResponse, error) {
+	if cc, ok := caller.(ContextCaller); ok {
+		return cc.CallRawContext(ctx, req)
+	}
+	return caller.CallRaw(req)
+}
+
+// APIMode selects which node APIs the Client methods call.
+type APIMode int
+
+const (
+	// CondenserAPI uses the legacy condenser_api methods with positional params.
+	CondenserAPI APIMode = iota
+	// AppbaseAPI uses the namespaced appbase APIs, such as database_api, with named params.
+	// Use it with nodes that disable condenser_api.
+	AppbaseAPI
+)
+
 // Client is used to pass data into unexposed functions.
 // When defining a new JSONrpc use the `NewClient()` function for Hive API defaults.
 // To specify an api endpoint execute `NewClient()` with a full URL.
+// Set API to AppbaseAPI to call the appbase APIs instead of condenser_api.
 type Client struct {
 	URL    string
 	Client Caller
+	API    APIMode
+
+	ctx context.Context
 }
 
 // NewClient creates an struct with Hive defaults.
 // If wish to use a different Hive endpoint (or a different Graphene blockchain
 // pass the URL as a parameter. Otherwise leave the parameters empty.
 // If more than one URL is entered, only the first will be used.
+// A ws:// or wss:// URL uses a persistent WebSocket connection instead of HTTP.
 // Example:
 // hive := NewClient()
 func NewClient(URL ...string) *Client {
@@ -34,15 +67,47 @@ func NewClient(URL ...string) *Client {
 	if len(URL) > 0 {
 		c.URL = URL[0]
 		c.Client = rpc.NewClient(URL[0])
+		if isWebSocketURL(URL[0]) {
+			c.Client = NewWSCaller(URL[0])
+		}
 	}
 	return c
 }
 
+// WithContext returns a copy of the Client whose calls carry ctx.
+// Example:
+// accs, err := hive.WithContext(ctx).GetAccounts("jrswab")
+func (c *Client) WithContext(ctx context.Context) *Client {
+	c2 := *c
+	c2.ctx = ctx
+	return &c2
+}
+
+// Context returns the context passed to WithContext, or context.Background().
+func (c *Client) Context() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+	return c.ctx
+}
+
 // GetAccountData retrieves the data requested by a method of type Client.
+// Without inputParams it sends an empty array, since hived rejects null params.
 func (c *Client) getAccountData(method string, inputParams ...interface{}) (*rpc.RPCResponse, error) {
-	request := rpc.NewRequest(method, inputParams)
+	if inputParams == nil {
+		inputParams = []interface{}{}
+	}
+	return c.call(rpc.NewRequest(method, inputParams))
+}
 
-	resp, err := c.Client.CallRaw(request)
+// getAPIData calls an appbase method with named params, given as a struct or map.
+func (c *Client) getAPIData(method string, params interface{}) (*rpc.RPCResponse, error) {
+	return c.call(rpc.NewRequest(method, params))
+}
+
+// call sends request and turns a JSON-RPC error into an error.
+func (c *Client) call(request *rpc.RPCRequest) (*rpc.RPCResponse, error) {
+	resp, err := CallContext(c.Context(), c.Client, request)
 	if err != nil {
 		return nil, fmt.Errorf("json rpc call error: %s", err)
 	}
@@ -52,3 +117,15 @@ func (c *Client) getAccountData(method string, inputParams ...interface{}) (*rpc
 	}
 	return resp, nil
 }
+
+// decodeResult is resp.GetObject keeping numbers in interface{} values as json.Number,
+// so large integers survive being decoded into maps.
+func decodeResult(resp *rpc.RPCResponse, out interface{}) error {
+	b, err := json.Marshal(resp.Result)
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	return dec.Decode(out)
+}
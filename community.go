@@ -0,0 +1,321 @@
+package gohive
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// maxCommunityLimit is the largest page the community lists return.
+const maxCommunityLimit = 100
+
+// CommunitySort is the order used by ListCommunities.
+type CommunitySort string
+
+// Orders accepted by bridge.list_communities.
+const (
+	CommunitiesByRank        CommunitySort = "rank"
+	CommunitiesByNew         CommunitySort = "new"
+	CommunitiesBySubscribers CommunitySort = "subs"
+)
+
+// Roles an account can have in a community, from least to most privileged.
+const (
+	RoleMuted  = "muted"
+	RoleGuest  = "guest"
+	RoleMember = "member"
+	RoleMod    = "mod"
+	RoleAdmin  = "admin"
+	RoleOwner  = "owner"
+)
+
+// CommunityContext is the relation of the observer to a community.
+type CommunityContext struct {
+	Role       string `json:"role"`
+	Subscribed bool   `json:"subscribed"`
+	Title      string `json:"title"`
+}
+
+// Community is a Hivemind community.
+type Community struct {
+	ID          int64                  `json:"id"`
+	Name        string                 `json:"name"`
+	Title       string                 `json:"title"`
+	About       string                 `json:"about"`
+	Description string                 `json:"description"`
+	FlagText    string                 `json:"flag_text"`
+	Lang        string                 `json:"lang"`
+	TypeID      int                    `json:"type_id"`
+	IsNsfw      bool                   `json:"is_nsfw"`
+	Subscribers int64                  `json:"subscribers"`
+	SumPending  float64                `json:"sum_pending"`
+	NumPending  int64                  `json:"num_pending"`
+	NumAuthors  int64                  `json:"num_authors"`
+	CreatedAt   Time                   `json:"created_at"`
+	AvatarURL   string                 `json:"avatar_url"`
+	Settings    map[string]interface{} `json:"settings"`
+	Context     CommunityContext       `json:"context"`
+	Team        []CommunityRole        `json:"team"`
+}
+
+// CommunityRole is the role and title of an account in a community.
+// Bridge returns it as an [account, role, title] list.
+type CommunityRole struct {
+	Account string
+	Role    string
+	Title   string
+}
+
+// UnmarshalJSON decodes an [account, role, title] list.
+func (r *CommunityRole) UnmarshalJSON(b []byte) error {
+	v, err := tupleStrings(b, 3)
+	if err != nil {
+		return err
+	}
+	*r = CommunityRole{Account: v[0], Role: v[1], Title: v[2]}
+	return nil
+}
+
+// Subscriber is an account subscribed to a community.
+// Bridge returns it as an [account, role, title, subscribed at] list.
+type Subscriber struct {
+	Account    string
+	Role       string
+	Title      string
+	Subscribed Time
+}
+
+// UnmarshalJSON decodes an [account, role, title, subscribed at] list.
+func (s *Subscriber) UnmarshalJSON(b []byte) error {
+	v, err := tupleStrings(b, 4)
+	if err != nil {
+		return err
+	}
+	*s = Subscriber{Account: v[0], Role: v[1], Title: v[2]}
+	if v[3] != "" {
+		if s.Subscribed.Time, err = parseAnyTime(v[3]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Subscription is a community an account is subscribed to.
+// Bridge returns it as a [community, title, role, team title] list.
+type Subscription struct {
+	Community string
+	Title     string
+	Role      string
+	TeamTitle string
+}
+
+// UnmarshalJSON decodes a [community, title, role, team title] list.
+func (s *Subscription) UnmarshalJSON(b []byte) error {
+	v, err := tupleStrings(b, 4)
+	if err != nil {
+		return err
+	}
+	*s = Subscription{Community: v[0], Title: v[1], Role: v[2], TeamTitle: v[3]}
+	return nil
+}
+
+// tupleStrings decodes a list of at least n values, returning the first n as strings.
+// null values become empty strings.
+func tupleStrings(b []byte, n int) ([]string, error) {
+	var list []*string
+	if err := json.Unmarshal(b, &list); err != nil {
+		return nil, err
+	}
+	if len(list) < n {
+		return nil, fmt.Errorf("expected %d values, got %s", n, b)
+	}
+	out := make([]string, n)
+	for i := range out {
+		if list[i] != nil {
+			out[i] = *list[i]
+		}
+	}
+	return out, nil
+}
+
+// GetCommunity returns the community name, as seen by observer. observer may be empty.
+func (c *Client) GetCommunity(name, observer string) (*Community, error) {
+	resp, err := c.getAPIData("bridge.get_community", struct {
+		Name     string `json:"name"`
+		Observer string `json:"observer,omitempty"`
+	}{name, observer})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Result == nil {
+		return nil, fmt.Errorf("community %s not found", name)
+	}
+
+	var out Community
+	if err := resp.GetObject(&out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListCommunities returns up to limit communities matching query in sort order.
+// query may be empty to list every community.
+func (c *Client) ListCommunities(query string, sort CommunitySort, limit int) ([]Community, error) {
+	if limit < 1 || limit > maxCommunityLimit {
+		return nil, fmt.Errorf("method ListCommunities needs a limit between 1 and %d", maxCommunityLimit)
+	}
+
+	resp, err := c.getAPIData("bridge.list_communities", struct {
+		Query string        `json:"query,omitempty"`
+		Sort  CommunitySort `json:"sort"`
+		Limit int           `json:"limit"`
+	}{query, sort, limit})
+	if err != nil {
+		return nil, err
+	}
+
+	out := []Community{}
+	if err := resp.GetObject(&out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListCommunityRoles returns up to limit accounts with a role in community, after the account last.
+func (c *Client) ListCommunityRoles(community, last string, limit int) ([]CommunityRole, error) {
+	if limit < 1 || limit > maxCommunityLimit {
+		return nil, fmt.Errorf("method ListCommunityRoles needs a limit between 1 and %d", maxCommunityLimit)
+	}
+
+	resp, err := c.getAPIData("bridge.list_community_roles", struct {
+		Community string `json:"community"`
+		Last      string `json:"last,omitempty"`
+		Limit     int    `json:"limit"`
+	}{community, last, limit})
+	if err != nil {
+		return nil, err
+	}
+
+	out := []CommunityRole{}
+	if err := resp.GetObject(&out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListSubscribers returns up to limit subscribers of community, after the account last.
+func (c *Client) ListSubscribers(community, last string, limit int) ([]Subscriber, error) {
+	if limit < 1 || limit > maxCommunityLimit {
+		return nil, fmt.Errorf("method ListSubscribers needs a limit between 1 and %d", maxCommunityLimit)
+	}
+
+	resp, err := c.getAPIData("bridge.list_subscribers", struct {
+		Community string `json:"community"`
+		Last      string `json:"last,omitempty"`
+		Limit     int    `json:"limit"`
+	}{community, last, limit})
+	if err != nil {
+		return nil, err
+	}
+
+	out := []Subscriber{}
+	if err := resp.GetObject(&out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListAllSubscriptions returns every community account is subscribed to.
+func (c *Client) ListAllSubscriptions(account string) ([]Subscription, error) {
+	resp, err := c.getAPIData("bridge.list_all_subscriptions", struct {
+		Account string `json:"account"`
+	}{account})
+	if err != nil {
+		return nil, err
+	}
+
+	out := []Subscription{}
+	if err := resp.GetObject(&out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CommunityAction is the body of community custom_json operations. The fields
+// used depend on the action.
+type CommunityAction struct {
+	Community string `json:"community"`
+	Account   string `json:"account,omitempty"`
+	Role      string `json:"role,omitempty"`
+	Title     string `json:"title,omitempty"`
+	Permlink  string `json:"permlink,omitempty"`
+	Notes     string `json:"notes,omitempty"`
+}
+
+// CommunityPayload is the payload of custom_json operations with the "community" id,
+// [action, {...}], such as ["subscribe", {"community": "hive-123456"}].
+type CommunityPayload struct {
+	Action string
+	CommunityAction
+}
+
+// MarshalJSON encodes the payload as [action, {...}].
+func (p CommunityPayload) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]interface{}{p.Action, p.CommunityAction})
+}
+
+// UnmarshalJSON decodes a [action, {...}] payload.
+func (p *CommunityPayload) UnmarshalJSON(b []byte) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(b, &raw); err != nil || len(raw) != 2 {
+		return fmt.Errorf("invalid community payload %s", b)
+	}
+	var out CommunityPayload
+	if err := json.Unmarshal(raw[0], &out.Action); err != nil {
+		return fmt.Errorf("invalid community payload action %s", raw[0])
+	}
+	if err := json.Unmarshal(raw[1], &out.CommunityAction); err != nil {
+		return err
+	}
+	*p = out
+	return nil
+}
+
+// communityOperation returns the custom_json operation for a community action by actor.
+func communityOperation(actor, action string, payload CommunityAction) CustomJSONOperation {
+	return postingCustomJSON(actor, "community", CommunityPayload{Action: action, CommunityAction: payload})
+}
+
+// CommunitySubscribe returns the operation subscribing actor to community.
+func CommunitySubscribe(actor, community string) CustomJSONOperation {
+	return communityOperation(actor, "subscribe", CommunityAction{Community: community})
+}
+
+// CommunityUnsubscribe returns the operation unsubscribing actor from community.
+func CommunityUnsubscribe(actor, community string) CustomJSONOperation {
+	return communityOperation(actor, "unsubscribe", CommunityAction{Community: community})
+}
+
+// CommunitySetRole returns the operation by which actor gives account role in community.
+func CommunitySetRole(actor, community, account, role string) CustomJSONOperation {
+	return communityOperation(actor, "setRole", CommunityAction{Community: community, Account: account, Role: role})
+}
+
+// CommunitySetUserTitle returns the operation by which actor gives account title in community.
+func CommunitySetUserTitle(actor, community, account, title string) CustomJSONOperation {
+	return communityOperation(actor, "setUserTitle", CommunityAction{Community: community, Account: account, Title: title})
+}
+
+// CommunityMutePost returns the operation by which actor mutes a post in community.
+func CommunityMutePost(actor, community, author, permlink, notes string) CustomJSONOperation {
+	return communityOperation(actor, "mutePost", CommunityAction{Community: community, Account: author, Permlink: permlink, Notes: notes})
+}
+
+// CommunityPinPost returns the operation by which actor pins a post in community.
+func CommunityPinPost(actor, community, author, permlink string) CustomJSONOperation {
+	return communityOperation(actor, "pinPost", CommunityAction{Community: community, Account: author, Permlink: permlink})
+}
+
+// CommunityFlagPost returns the operation by which actor reports a post to the community moderators.
+func CommunityFlagPost(actor, community, author, permlink, notes string) CustomJSONOperation {
+	return communityOperation(actor, "flagPost", CommunityAction{Community: community, Account: author, Permlink: permlink, Notes: notes})
+}
@@ -0,0 +1,80 @@
+package gohive
+
+import "fmt"
+
+// CollateralizedConversionFee is the fee, in basis points, taken by collateralized conversions.
+const CollateralizedConversionFee = 500
+
+// ConversionRequest is a pending HBD to HIVE conversion, settled at ConversionDate.
+type ConversionRequest struct {
+	ID             int64  `json:"id"`
+	Owner          string `json:"owner"`
+	RequestID      uint32 `json:"requestid"`
+	Amount         Asset  `json:"amount"`
+	ConversionDate Time   `json:"conversion_date"`
+}
+
+// CollateralizedConversionRequest is a pending HIVE to HBD conversion.
+// ConvertedAmount was paid out when the request was made; at ConversionDate the
+// collateral needed to cover it is burned and the rest returned to the owner.
+type CollateralizedConversionRequest struct {
+	ID               int64  `json:"id"`
+	Owner            string `json:"owner"`
+	RequestID        uint32 `json:"requestid"`
+	CollateralAmount Asset  `json:"collateral_amount"`
+	ConvertedAmount  Asset  `json:"converted_amount"`
+	ConversionDate   Time   `json:"conversion_date"`
+}
+
+// GetConversionRequests returns the pending HBD to HIVE conversions of account.
+func (c *Client) GetConversionRequests(account string) ([]ConversionRequest, error) {
+	resp, err := c.getAccountData("get_conversion_requests", account)
+	if err != nil {
+		return nil, err
+	}
+
+	out := []ConversionRequest{}
+	if err := resp.GetObject(&out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetCollateralizedConversionRequests returns the pending HIVE to HBD conversions of account.
+func (c *Client) GetCollateralizedConversionRequests(account string) ([]CollateralizedConversionRequest, error) {
+	resp, err := c.getAccountData("get_collateralized_conversion_requests", account)
+	if err != nil {
+		return nil, err
+	}
+
+	out := []CollateralizedConversionRequest{}
+	if err := resp.GetObject(&out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// EstimateSettlement returns the HIVE the request pays out if it settles at the median price.
+func (r ConversionRequest) EstimateSettlement(median Price) (Asset, error) {
+	return median.Convert(r.Amount)
+}
+
+// EstimateSettlement returns the collateral returned to the owner if the request
+// settles at the median price. The HIVE kept covers ConvertedAmount plus the
+// CollateralizedConversionFee; nothing is returned when the collateral falls short.
+func (r CollateralizedConversionRequest) EstimateSettlement(median Price) (Asset, error) {
+	required, err := median.convertWithFee(r.ConvertedAmount, CollateralizedConversionFee)
+	if err != nil {
+		return Asset{}, err
+	}
+	if required.Symbol != r.CollateralAmount.Symbol {
+		return Asset{}, fmt.Errorf("collateral is %s but the price converts to %s", r.CollateralAmount.Symbol, required.Symbol)
+	}
+
+	out := r.CollateralAmount
+	out.Amount -= required.Amount
+	if out.Amount < 0 {
+		out.Amount = 0
+	}
+	return out, nil
+}
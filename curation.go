@@ -0,0 +1,180 @@
+package gohive
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// Curation windows of the current hardfork. Votes in the reverse auction lose part of
+// their weight to the reward pool, and votes after the early window count for less.
+const (
+	ReverseAuctionWindow = 300 * time.Second
+	EarlyVotingWindow    = 24 * time.Hour
+	MidVotingWindow      = 48 * time.Hour
+)
+
+// CurationVote is a vote as counted for curation rewards.
+type CurationVote struct {
+	Voter   string
+	Rshares int64
+	Time    time.Time
+}
+
+// CurationReward is the curation reward of a voter.
+type CurationReward struct {
+	Voter  string
+	Reward Asset
+}
+
+// CurationVotes returns the votes of p for the curation reward functions.
+func CurationVotes(p Post) ([]CurationVote, error) {
+	out := make([]CurationVote, 0, len(p.ActiveVotes))
+	for _, v := range p.ActiveVotes {
+		rshares, err := v.Rshares.Int64()
+		if err != nil {
+			return nil, fmt.Errorf("invalid rshares %q of vote by %s", v.Rshares, v.Voter)
+		}
+		out = append(out, CurationVote{Voter: v.Voter, Rshares: rshares, Time: v.Time.Time})
+	}
+	return out, nil
+}
+
+// CurationWeight returns the weight of a vote with rshares cast elapsed after the post was created.
+// max is the weight the vote adds to the total; weight is what the voter is paid for,
+// lower than max during the reverse auction. Downvotes have no weight.
+func CurationWeight(elapsed time.Duration, rshares int64) (weight, max int64) {
+	if rshares <= 0 {
+		return 0, 0
+	}
+	max = rshares
+	switch {
+	case elapsed >= EarlyVotingWindow+MidVotingWindow:
+		max /= 8
+	case elapsed >= EarlyVotingWindow:
+		max /= 2
+	}
+
+	weight = max
+	if elapsed < 0 {
+		elapsed = 0
+	}
+	if elapsed < ReverseAuctionWindow {
+		w := new(big.Int).Mul(big.NewInt(max), big.NewInt(int64(elapsed/time.Second)))
+		weight = w.Div(w, big.NewInt(int64(ReverseAuctionWindow/time.Second))).Int64()
+	}
+	return weight, max
+}
+
+// CurationRewards splits pool, the curation part of a payout, between the votes on a
+// post created at created. The share lost in the reverse auction is not paid out.
+func CurationRewards(created time.Time, votes []CurationVote, pool Asset) []CurationReward {
+	weights := make([]int64, len(votes))
+	total := new(big.Int)
+	for i, v := range votes {
+		w, max := CurationWeight(v.Time.Sub(created), v.Rshares)
+		weights[i] = w
+		total.Add(total, big.NewInt(max))
+	}
+
+	out := make([]CurationReward, 0, len(votes))
+	for i, v := range votes {
+		reward := pool
+		reward.Amount = 0
+		if total.Sign() > 0 {
+			r := new(big.Int).Mul(big.NewInt(pool.Amount), big.NewInt(weights[i]))
+			reward.Amount = r.Div(r, total).Int64()
+		}
+		out = append(out, CurationReward{Voter: v.Voter, Reward: reward})
+	}
+	return out
+}
+
+// EstimateCurationReward estimates the HIVE, paid as Hive Power, that vote would earn
+// on a post created at created with the existing votes, if nothing else changed
+// until payout.
+func EstimateCurationReward(created time.Time, existing []CurationVote, vote CurationVote, fund RewardFund) (Asset, error) {
+	votes := append(append([]CurationVote{}, existing...), vote)
+	var rshares int64
+	for _, v := range votes {
+		rshares += v.Rshares
+	}
+
+	payout, err := fund.RsharesToHive(rshares)
+	if err != nil {
+		return Asset{}, err
+	}
+	pool := payout
+	pool.Amount = payout.Amount * int64(fund.PercentCurationRewards) / percent100
+
+	rewards := CurationRewards(created, votes, pool)
+	return rewards[len(rewards)-1].Reward, nil
+}
+
+// CurationRewardOperation is the curation_reward virtual operation paying a curator.
+type CurationRewardOperation struct {
+	Curator             string `json:"curator"`
+	Reward              Asset  `json:"reward"`
+	CommentAuthor       string `json:"comment_author"`
+	CommentPermlink     string `json:"comment_permlink"`
+	PayoutMustBeClaimed bool   `json:"payout_must_be_claimed"`
+}
+
+// HistoryCurationRewards returns the curation_reward operations in entries, as
+// decoded by DecodeAccountHistory, for use with ReconcileCurationRewards.
+func HistoryCurationRewards(entries []HistoryEntry) []CurationRewardOperation {
+	out := []CurationRewardOperation{}
+	for _, e := range entries {
+		if op, ok := e.Virtual.(*CurationRewardOperation); ok {
+			out = append(out, *op)
+		}
+	}
+	return out
+}
+
+// CurationReconciliation compares the predicted and actual curation reward of a curator.
+// Diff is Actual minus Predicted.
+type CurationReconciliation struct {
+	Curator   string
+	Predicted Asset
+	Actual    Asset
+	Diff      Asset
+}
+
+// ReconcileCurationRewards matches predicted rewards, in HIVE, against the curation_reward
+// operations paid out, in VESTS, converted with vestingPrice from
+// DynamicGlobalProperties.VestingPrice. Curators missing from either side count as 0.
+func ReconcileCurationRewards(predicted []CurationReward, actual []CurationRewardOperation, vestingPrice Price) ([]CurationReconciliation, error) {
+	zero := Asset{Precision: vestingPrice.Base.Precision, Symbol: vestingPrice.Base.Symbol}
+	out := []CurationReconciliation{}
+	index := map[string]int{}
+	entry := func(curator string) *CurationReconciliation {
+		i, ok := index[curator]
+		if !ok {
+			i = len(out)
+			index[curator] = i
+			out = append(out, CurationReconciliation{Curator: curator, Predicted: zero, Actual: zero})
+		}
+		return &out[i]
+	}
+
+	for _, p := range predicted {
+		if p.Reward.Symbol != zero.Symbol {
+			return nil, fmt.Errorf("predicted reward of %s is %s, want %s", p.Voter, p.Reward.Symbol, zero.Symbol)
+		}
+		entry(p.Voter).Predicted.Amount += p.Reward.Amount
+	}
+	for _, op := range actual {
+		hive, err := vestingPrice.Convert(op.Reward)
+		if err != nil {
+			return nil, err
+		}
+		entry(op.Curator).Actual.Amount += hive.Amount
+	}
+
+	for i := range out {
+		out[i].Diff = out[i].Actual
+		out[i].Diff.Amount -= out[i].Predicted.Amount
+	}
+	return out, nil
+}
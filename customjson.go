@@ -0,0 +1,161 @@
+package gohive
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// ErrUnregisteredCustomJSON is returned by CustomJSONOperation.Payload when no
+// type is registered for the id of the operation.
+var ErrUnregisteredCustomJSON = errors.New("no payload type registered for custom_json id")
+
+// customJSONTypes maps custom_json ids to the payload types registered with RegisterCustomJSON.
+var (
+	customJSONMu    sync.RWMutex
+	customJSONTypes = map[string]reflect.Type{}
+)
+
+func init() {
+	RegisterCustomJSON("follow", FollowPayload{})
+	RegisterCustomJSON("community", CommunityPayload{})
+}
+
+// RegisterCustomJSON makes the payload of custom_json operations with id decode
+// into the type of payload, which is usually a zero struct:
+//
+//	type Podping struct {
+//		Version string   `json:"version"`
+//		IRIs    []string `json:"iris"`
+//	}
+//	gohive.RegisterCustomJSON("pp_podcast_update", Podping{})
+//
+// It replaces any type registered for id; a nil payload removes it. The "follow"
+// and "community" ids are registered with FollowPayload and CommunityPayload.
+func RegisterCustomJSON(id string, payload interface{}) {
+	customJSONMu.Lock()
+	defer customJSONMu.Unlock()
+	if payload == nil {
+		delete(customJSONTypes, id)
+		return
+	}
+	t := reflect.TypeOf(payload)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	customJSONTypes[id] = t
+}
+
+// customJSONType returns the payload type registered for id.
+func customJSONType(id string) (reflect.Type, bool) {
+	customJSONMu.RLock()
+	defer customJSONMu.RUnlock()
+	t, ok := customJSONTypes[id]
+	return t, ok
+}
+
+// Payload decodes JSON into a new value of the type registered for ID and returns
+// a pointer to it, such as *FollowPayload. It returns ErrUnregisteredCustomJSON
+// when no type is registered.
+func (op CustomJSONOperation) Payload() (interface{}, error) {
+	t, ok := customJSONType(op.ID)
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnregisteredCustomJSON, op.ID)
+	}
+	v := reflect.New(t)
+	if err := json.Unmarshal([]byte(op.JSON), v.Interface()); err != nil {
+		return nil, fmt.Errorf("invalid %s custom_json payload: %v", op.ID, err)
+	}
+	return v.Interface(), nil
+}
+
+// HistoryEntry is an operation in the history of an account, as decoded by DecodeAccountHistory.
+type HistoryEntry struct {
+	Index      int64
+	TrxID      string
+	Block      int64
+	TrxInBlock int
+	OpInTrx    int
+	Timestamp  Time
+	OpType     OpType
+	// Op is the operation when it is one this package can broadcast, otherwise nil.
+	Op Operation
+	// Virtual is the operation when it is a virtual operation this package has a type for,
+	// such as *CurationRewardOperation, otherwise nil.
+	Virtual VirtualOperation
+	// Payload is the decoded payload of custom_json operations with a registered id.
+	// It is nil when the id is not registered or the payload does not decode.
+	Payload interface{}
+	// RawOp is the operation in the condenser form, ["vote", {...}]. It is the only
+	// form of operations with neither Op nor Virtual set.
+	RawOp json.RawMessage
+}
+
+// DecodeAccountHistory decodes the result of GetAccountHistory into typed entries.
+// Operations decode into Op or Virtual depending on their kind. The payloads of custom_json operations are decoded with the registered types.
+func DecodeAccountHistory(history interface{}) ([]HistoryEntry, error) {
+	b, err := json.Marshal(history)
+	if err != nil {
+		return nil, err
+	}
+	var entries [][]json.RawMessage
+	if err := json.Unmarshal(b, &entries); err != nil {
+		return nil, fmt.Errorf("invalid account history: %v", err)
+	}
+
+	out := make([]HistoryEntry, 0, len(entries))
+	for _, entry := range entries {
+		if len(entry) != 2 {
+			return nil, fmt.Errorf("invalid account history entry of %d items", len(entry))
+		}
+		var index int64
+		if err := json.Unmarshal(entry[0], &index); err != nil {
+			return nil, fmt.Errorf("invalid account history index %s", entry[0])
+		}
+		var value struct {
+			TrxID      string          `json:"trx_id"`
+			Block      int64           `json:"block"`
+			TrxInBlock int             `json:"trx_in_block"`
+			OpInTrx    int             `json:"op_in_trx"`
+			Timestamp  Time            `json:"timestamp"`
+			Op         json.RawMessage `json:"op"`
+		}
+		if err := json.Unmarshal(entry[1], &value); err != nil {
+			return nil, fmt.Errorf("invalid account history entry %d: %v", index, err)
+		}
+		var op []json.RawMessage
+		if err := json.Unmarshal(value.Op, &op); err != nil || len(op) != 2 {
+			return nil, fmt.Errorf("invalid operation in account history entry %d", index)
+		}
+
+		e := HistoryEntry{
+			Index:      index,
+			TrxID:      value.TrxID,
+			Block:      value.Block,
+			TrxInBlock: value.TrxInBlock,
+			OpInTrx:    value.OpInTrx,
+			Timestamp:  value.Timestamp,
+			RawOp:      value.Op,
+		}
+		if err := json.Unmarshal(op[0], &e.OpType); err != nil {
+			return nil, fmt.Errorf("invalid operation name in account history entry %d", index)
+		}
+		if _, ok := operationTypes[e.OpType]; ok {
+			if e.Op, err = UnmarshalOperation(value.Op); err != nil {
+				return nil, fmt.Errorf("account history entry %d: %v", index, err)
+			}
+		}
+		if _, ok := virtualOperationTypes[e.OpType]; ok {
+			if e.Virtual, err = UnmarshalVirtualOperation(value.Op); err != nil {
+				return nil, fmt.Errorf("account history entry %d: %v", index, err)
+			}
+		}
+		if cj, ok := e.Op.(*CustomJSONOperation); ok {
+			e.Payload, _ = cj.Payload()
+		}
+		out = append(out, e)
+	}
+	return out, nil
+}
@@ -0,0 +1,136 @@
+package gohive
+
+import (
+	"fmt"
+	"time"
+)
+
+// VestingDelegation is a delegation of vesting shares from one account to another.
+type VestingDelegation struct {
+	ID                int64  `json:"id"`
+	Delegator         string `json:"delegator"`
+	Delegatee         string `json:"delegatee"`
+	VestingShares     Asset  `json:"vesting_shares"`
+	MinDelegationTime Time   `json:"min_delegation_time"`
+}
+
+// ExpiringVestingDelegation is a removed delegation whose vesting shares have
+// not returned to the delegator yet.
+type ExpiringVestingDelegation struct {
+	ID            int64  `json:"id"`
+	Delegator     string `json:"delegator"`
+	VestingShares Asset  `json:"vesting_shares"`
+	Expiration    Time   `json:"expiration"`
+}
+
+// GetVestingDelegations returns up to limit outgoing delegations of delegator,
+// ordered by delegatee and starting at from.
+func (c *Client) GetVestingDelegations(delegator, from string, limit int) ([]VestingDelegation, error) {
+	if limit < 1 || limit > maxListLimit {
+		return nil, fmt.Errorf("method GetVestingDelegations needs a limit between 1 and %d", maxListLimit)
+	}
+
+	resp, err := c.getAccountData("get_vesting_delegations", delegator, from, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	out := []VestingDelegation{}
+	if err := resp.GetObject(&out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetAllVestingDelegations returns every outgoing delegation of delegator,
+// paging through GetVestingDelegations.
+func (c *Client) GetAllVestingDelegations(delegator string) ([]VestingDelegation, error) {
+	out := []VestingDelegation{}
+	from := ""
+	for {
+		page, err := c.GetVestingDelegations(delegator, from, maxListLimit)
+		if err != nil {
+			return nil, err
+		}
+
+		full := len(page) == maxListLimit
+		// from is inclusive, so every page after the first repeats the last delegation.
+		if len(out) > 0 && len(page) > 0 && page[0].Delegatee == from {
+			page = page[1:]
+		}
+		out = append(out, page...)
+		if !full || len(page) == 0 {
+			return out, nil
+		}
+		from = page[len(page)-1].Delegatee
+	}
+}
+
+// FindVestingDelegations returns every outgoing delegation of account using database_api.find_vesting_delegations.
+func (c *Client) FindVestingDelegations(account string) ([]VestingDelegation, error) {
+	resp, err := c.getAPIData("database_api.find_vesting_delegations", struct {
+		Account string `json:"account"`
+	}{account})
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Delegations []VestingDelegation `json:"delegations"`
+	}
+	if err := resp.GetObject(&result); err != nil {
+		return nil, err
+	}
+	if result.Delegations == nil {
+		result.Delegations = []VestingDelegation{}
+	}
+	return result.Delegations, nil
+}
+
+// GetExpiringVestingDelegations returns up to limit delegations removed by account
+// which expire at or after from.
+func (c *Client) GetExpiringVestingDelegations(account string, from time.Time, limit int) ([]ExpiringVestingDelegation, error) {
+	if limit < 1 || limit > maxListLimit {
+		return nil, fmt.Errorf("method GetExpiringVestingDelegations needs a limit between 1 and %d", maxListLimit)
+	}
+
+	resp, err := c.getAccountData("get_expiring_vesting_delegations", account, Time{from}, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	out := []ExpiringVestingDelegation{}
+	if err := resp.GetObject(&out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetAllExpiringVestingDelegations returns every delegation removed by account
+// which has not expired yet, paging through GetExpiringVestingDelegations.
+func (c *Client) GetAllExpiringVestingDelegations(account string) ([]ExpiringVestingDelegation, error) {
+	out := []ExpiringVestingDelegation{}
+	seen := map[int64]bool{}
+	from := time.Unix(0, 0)
+	for {
+		page, err := c.GetExpiringVestingDelegations(account, from, maxListLimit)
+		if err != nil {
+			return nil, err
+		}
+
+		added := 0
+		for _, d := range page {
+			// from is inclusive, so delegations expiring at the same time show up on both pages.
+			if seen[d.ID] {
+				continue
+			}
+			seen[d.ID] = true
+			out = append(out, d)
+			added++
+		}
+		if len(page) < maxListLimit || added == 0 {
+			return out, nil
+		}
+		from = page[len(page)-1].Expiration.Time
+	}
+}
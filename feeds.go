@@ -0,0 +1,184 @@
+package gohive
+
+import "fmt"
+
+// maxBridgeLimit is the largest page the bridge post lists return.
+const maxBridgeLimit = 100
+
+// RankedSort is the ranking used by GetRankedPosts.
+type RankedSort string
+
+// Rankings accepted by bridge.get_ranked_posts.
+const (
+	RankedTrending RankedSort = "trending"
+	RankedHot      RankedSort = "hot"
+	RankedCreated  RankedSort = "created"
+	RankedPromoted RankedSort = "promoted"
+	RankedPayout   RankedSort = "payout"
+	RankedMuted    RankedSort = "muted"
+)
+
+// AccountPostsSort selects the posts returned by GetAccountPosts.
+type AccountPostsSort string
+
+// Sorts accepted by bridge.get_account_posts.
+const (
+	AccountBlog     AccountPostsSort = "blog"
+	AccountFeed     AccountPostsSort = "feed"
+	AccountPosts    AccountPostsSort = "posts"
+	AccountComments AccountPostsSort = "comments"
+	AccountReplies  AccountPostsSort = "replies"
+	AccountPayout   AccountPostsSort = "payout"
+)
+
+// PostCursor is the post a bridge list starts after. The zero value starts at the beginning.
+type PostCursor struct {
+	Author   string
+	Permlink string
+}
+
+// Cursor returns the cursor continuing a list after p.
+func (p Post) Cursor() PostCursor {
+	return PostCursor{Author: p.Author, Permlink: p.Permlink}
+}
+
+// GetRankedPosts returns up to limit posts ranked by sort, after start.
+// tag filters by tag or community name (e.g. "hive-123456") and may be empty;
+// observer, when set, applies the mutes of that account.
+func (c *Client) GetRankedPosts(sort RankedSort, tag, observer string, start PostCursor, limit int) ([]Post, error) {
+	if limit < 1 || limit > maxBridgeLimit {
+		return nil, fmt.Errorf("method GetRankedPosts needs a limit between 1 and %d", maxBridgeLimit)
+	}
+
+	resp, err := c.getAPIData("bridge.get_ranked_posts", struct {
+		Sort          RankedSort `json:"sort"`
+		Tag           string     `json:"tag,omitempty"`
+		Observer      string     `json:"observer,omitempty"`
+		StartAuthor   string     `json:"start_author,omitempty"`
+		StartPermlink string     `json:"start_permlink,omitempty"`
+		Limit         int        `json:"limit"`
+	}{sort, tag, observer, start.Author, start.Permlink, limit})
+	if err != nil {
+		return nil, err
+	}
+
+	out := []Post{}
+	if err := resp.GetObject(&out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetAccountPosts returns up to limit posts of account selected by sort, after start.
+func (c *Client) GetAccountPosts(sort AccountPostsSort, account, observer string, start PostCursor, limit int) ([]Post, error) {
+	if limit < 1 || limit > maxBridgeLimit {
+		return nil, fmt.Errorf("method GetAccountPosts needs a limit between 1 and %d", maxBridgeLimit)
+	}
+
+	resp, err := c.getAPIData("bridge.get_account_posts", struct {
+		Sort          AccountPostsSort `json:"sort"`
+		Account       string           `json:"account"`
+		Observer      string           `json:"observer,omitempty"`
+		StartAuthor   string           `json:"start_author,omitempty"`
+		StartPermlink string           `json:"start_permlink,omitempty"`
+		Limit         int              `json:"limit"`
+	}{sort, account, observer, start.Author, start.Permlink, limit})
+	if err != nil {
+		return nil, err
+	}
+
+	out := []Post{}
+	if err := resp.GetObject(&out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PostIterator walks through a bridge post list, one page at a time.
+// Example:
+//
+//	it := c.IterateRankedPosts(RankedTrending, "hive-dev", "", 20)
+//	for it.Next() {
+//		fmt.Println(it.Post().Title)
+//	}
+//	if err := it.Err(); err != nil {
+//		fmt.Println(err)
+//	}
+type PostIterator struct {
+	fetch    func(start PostCursor, limit int) ([]Post, error)
+	pageSize int
+	cursor   PostCursor
+	page     []Post
+	pos      int
+	done     bool
+	err      error
+}
+
+// IterateRankedPosts returns a PostIterator over GetRankedPosts fetching pageSize posts per call.
+func (c *Client) IterateRankedPosts(sort RankedSort, tag, observer string, pageSize int) *PostIterator {
+	return newPostIterator(func(start PostCursor, limit int) ([]Post, error) {
+		return c.GetRankedPosts(sort, tag, observer, start, limit)
+	}, pageSize)
+}
+
+// IterateAccountPosts returns a PostIterator over GetAccountPosts fetching pageSize posts per call.
+func (c *Client) IterateAccountPosts(sort AccountPostsSort, account, observer string, pageSize int) *PostIterator {
+	return newPostIterator(func(start PostCursor, limit int) ([]Post, error) {
+		return c.GetAccountPosts(sort, account, observer, start, limit)
+	}, pageSize)
+}
+
+func newPostIterator(fetch func(start PostCursor, limit int) ([]Post, error), pageSize int) *PostIterator {
+	if pageSize < 1 || pageSize > maxBridgeLimit {
+		pageSize = maxBridgeLimit
+	}
+	return &PostIterator{fetch: fetch, pageSize: pageSize, pos: -1}
+}
+
+// Next advances to the next post, fetching a new page when needed.
+// It returns false when there are no posts left or a call failed.
+func (it *PostIterator) Next() bool {
+	it.pos++
+	if it.pos < len(it.page) {
+		return true
+	}
+	if it.done || it.err != nil {
+		return false
+	}
+
+	page, err := it.fetch(it.cursor, it.pageSize)
+	if err != nil {
+		it.err = err
+		return false
+	}
+
+	if len(page) < it.pageSize {
+		it.done = true
+	}
+	// Bridge starts after the cursor, but skip it should a node repeat it.
+	if len(page) > 0 && page[0].Cursor() == it.cursor {
+		page = page[1:]
+	}
+	if len(page) == 0 {
+		it.done = true
+		return false
+	}
+
+	it.cursor = page[len(page)-1].Cursor()
+	it.page = page
+	it.pos = 0
+	return true
+}
+
+// Post returns the current post.
+func (it *PostIterator) Post() Post {
+	if it.pos < 0 || it.pos >= len(it.page) {
+		return Post{}
+	}
+	return it.page[it.pos]
+}
+
+// Err returns the error which stopped the iteration, if any.
+func (it *PostIterator) Err() error {
+	return it.err
+}
@@ -0,0 +1,284 @@
+package gohive
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// FollowType is the kind of follow listed by GetFollowers and GetFollowing.
+type FollowType string
+
+// Follow types accepted by get_followers and get_following.
+const (
+	FollowBlog   FollowType = "blog"
+	FollowIgnore FollowType = "ignore"
+)
+
+// Follow is a follow or mute of Following by Follower. What holds the follow types.
+type Follow struct {
+	Follower  string   `json:"follower"`
+	Following string   `json:"following"`
+	What      []string `json:"what"`
+}
+
+// FollowCount holds the number of followers and followed accounts of Account.
+type FollowCount struct {
+	Account        string `json:"account"`
+	FollowerCount  int64  `json:"follower_count"`
+	FollowingCount int64  `json:"following_count"`
+}
+
+// Relationship describes how one account relates to another.
+type Relationship struct {
+	Follows           bool `json:"follows"`
+	Ignores           bool `json:"ignores"`
+	Blacklists        bool `json:"blacklists"`
+	FollowsBlacklists bool `json:"follows_blacklists"`
+	FollowsMuted      bool `json:"follows_muted"`
+}
+
+// GetFollowers returns up to limit followers of account of type, in alphabetical order starting at start.
+func (c *Client) GetFollowers(account, start string, followType FollowType, limit int) ([]Follow, error) {
+	return c.getFollows("get_followers", account, start, followType, limit)
+}
+
+// GetFollowing returns up to limit accounts followed by account with type, in alphabetical order starting at start.
+func (c *Client) GetFollowing(account, start string, followType FollowType, limit int) ([]Follow, error) {
+	return c.getFollows("get_following", account, start, followType, limit)
+}
+
+func (c *Client) getFollows(method, account, start string, followType FollowType, limit int) ([]Follow, error) {
+	if limit < 1 || limit > maxListLimit {
+		return nil, fmt.Errorf("method %s needs a limit between 1 and %d", method, maxListLimit)
+	}
+
+	resp, err := c.getAccountData(method, account, start, followType, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	out := []Follow{}
+	if err := resp.GetObject(&out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetFollowCount returns the follower and following counts of account.
+func (c *Client) GetFollowCount(account string) (*FollowCount, error) {
+	resp, err := c.getAccountData("get_follow_count", account)
+	if err != nil {
+		return nil, err
+	}
+
+	var out FollowCount
+	if err := resp.GetObject(&out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetRelationship returns how account1 relates to account2 using bridge.get_relationship_between_accounts.
+func (c *Client) GetRelationship(account1, account2 string) (*Relationship, error) {
+	resp, err := c.getAPIData("bridge.get_relationship_between_accounts", struct {
+		Account1 string `json:"account1"`
+		Account2 string `json:"account2"`
+	}{account1, account2})
+	if err != nil {
+		return nil, err
+	}
+
+	var out Relationship
+	if err := resp.GetObject(&out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// FollowIterator walks through a follower or following list, one page at a time.
+// Example:
+//
+//	it := c.IterateFollowers("jrswab", FollowBlog, 1000)
+//	for it.Next() {
+//		fmt.Println(it.Follow().Follower)
+//	}
+//	if err := it.Err(); err != nil {
+//		fmt.Println(err)
+//	}
+type FollowIterator struct {
+	fetch    func(start string, limit int) ([]Follow, error)
+	key      func(f Follow) string
+	pageSize int
+	cursor   string
+	page     []Follow
+	pos      int
+	done     bool
+	err      error
+}
+
+// IterateFollowers returns a FollowIterator over the followers of account, fetching pageSize per call.
+// Pages overlap by one account, so pageSize must be at least 2.
+func (c *Client) IterateFollowers(account string, followType FollowType, pageSize int) *FollowIterator {
+	return newFollowIterator(func(start string, limit int) ([]Follow, error) {
+		return c.GetFollowers(account, start, followType, limit)
+	}, func(f Follow) string { return f.Follower }, pageSize)
+}
+
+// IterateFollowing returns a FollowIterator over the accounts followed by account, fetching pageSize per call.
+// Pages overlap by one account, so pageSize must be at least 2.
+func (c *Client) IterateFollowing(account string, followType FollowType, pageSize int) *FollowIterator {
+	return newFollowIterator(func(start string, limit int) ([]Follow, error) {
+		return c.GetFollowing(account, start, followType, limit)
+	}, func(f Follow) string { return f.Following }, pageSize)
+}
+
+func newFollowIterator(fetch func(start string, limit int) ([]Follow, error), key func(f Follow) string, pageSize int) *FollowIterator {
+	if pageSize < 2 {
+		pageSize = 2
+	}
+	return &FollowIterator{fetch: fetch, key: key, pageSize: pageSize, pos: -1}
+}
+
+// Next advances to the next follow, fetching a new page when needed.
+// It returns false when there are no follows left or a call failed.
+func (it *FollowIterator) Next() bool {
+	it.pos++
+	if it.pos < len(it.page) {
+		return true
+	}
+	if it.done || it.err != nil {
+		return false
+	}
+
+	page, err := it.fetch(it.cursor, it.pageSize)
+	if err != nil {
+		it.err = err
+		return false
+	}
+
+	if len(page) < it.pageSize {
+		it.done = true
+	}
+	// The start is inclusive, so later pages start with the last account of the previous one.
+	if len(it.page) > 0 && len(page) > 0 && it.key(page[0]) == it.cursor {
+		page = page[1:]
+	}
+	if len(page) == 0 {
+		it.done = true
+		return false
+	}
+
+	it.cursor = it.key(page[len(page)-1])
+	it.page = page
+	it.pos = 0
+	return true
+}
+
+// Follow returns the current follow.
+func (it *FollowIterator) Follow() Follow {
+	if it.pos < 0 || it.pos >= len(it.page) {
+		return Follow{}
+	}
+	return it.page[it.pos]
+}
+
+// Err returns the error which stopped the iteration, if any.
+func (it *FollowIterator) Err() error {
+	return it.err
+}
+
+// FollowAction is the payload of follow, unfollow and mute custom_json operations.
+// An empty What unfollows.
+type FollowAction struct {
+	Follower  string   `json:"follower"`
+	Following string   `json:"following"`
+	What      []string `json:"what"`
+}
+
+// ReblogAction is the payload of reblog custom_json operations. Delete is "delete"
+// when the reblog is undone.
+type ReblogAction struct {
+	Account  string `json:"account"`
+	Author   string `json:"author"`
+	Permlink string `json:"permlink"`
+	Delete   string `json:"delete,omitempty"`
+}
+
+// FollowPayload is the payload of custom_json operations with the "follow" id,
+// ["follow", {...}] or ["reblog", {...}]. Follow or Reblog is set depending on Action.
+type FollowPayload struct {
+	Action string
+	Follow *FollowAction
+	Reblog *ReblogAction
+}
+
+// MarshalJSON encodes the payload as [action, {...}].
+func (p FollowPayload) MarshalJSON() ([]byte, error) {
+	if p.Action == "reblog" {
+		return json.Marshal([]interface{}{p.Action, p.Reblog})
+	}
+	return json.Marshal([]interface{}{p.Action, p.Follow})
+}
+
+// UnmarshalJSON decodes a [action, {...}] payload, or the bare follow object of old operations.
+func (p *FollowPayload) UnmarshalJSON(b []byte) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(b, &raw); err != nil {
+		var f FollowAction
+		if err := json.Unmarshal(b, &f); err != nil {
+			return fmt.Errorf("invalid follow payload %s", b)
+		}
+		*p = FollowPayload{Action: "follow", Follow: &f}
+		return nil
+	}
+	if len(raw) != 2 {
+		return fmt.Errorf("invalid follow payload %s", b)
+	}
+
+	var action string
+	if err := json.Unmarshal(raw[0], &action); err != nil {
+		return fmt.Errorf("invalid follow payload action %s", raw[0])
+	}
+	switch action {
+	case "follow":
+		var f FollowAction
+		if err := json.Unmarshal(raw[1], &f); err != nil {
+			return err
+		}
+		*p = FollowPayload{Action: action, Follow: &f}
+	case "reblog":
+		var r ReblogAction
+		if err := json.Unmarshal(raw[1], &r); err != nil {
+			return err
+		}
+		*p = FollowPayload{Action: action, Reblog: &r}
+	default:
+		return fmt.Errorf("unknown follow payload action %q", action)
+	}
+	return nil
+}
+
+// followOperation returns the custom_json operation for a follow action by follower.
+func followOperation(follower, following string, what []string) CustomJSONOperation {
+	return postingCustomJSON(follower, "follow", FollowPayload{Action: "follow", Follow: &FollowAction{follower, following, what}})
+}
+
+// FollowAccount returns the operation by which follower follows following.
+func FollowAccount(follower, following string) CustomJSONOperation {
+	return followOperation(follower, following, []string{string(FollowBlog)})
+}
+
+// UnfollowAccount returns the operation by which follower stops following or muting following.
+func UnfollowAccount(follower, following string) CustomJSONOperation {
+	return followOperation(follower, following, []string{})
+}
+
+// MuteAccount returns the operation by which follower mutes following.
+func MuteAccount(follower, following string) CustomJSONOperation {
+	return followOperation(follower, following, []string{string(FollowIgnore)})
+}
+
+// Reblog returns the operation by which account reblogs author/permlink to its blog.
+func Reblog(account, author, permlink string) CustomJSONOperation {
+	return postingCustomJSON(account, "follow", FollowPayload{Action: "reblog", Reblog: &ReblogAction{Account: account, Author: author, Permlink: permlink}})
+}
@@ -0,0 +1,43 @@
+package gohive
+
+import rpc "github.com/ybbus/jsonrpc"
+
+// DynamicGlobalProperties holds the current state of the chain.
+type DynamicGlobalProperties struct {
+	HeadBlockNumber          int64  `json:"head_block_number"`
+	HeadBlockID              string `json:"head_block_id"`
+	Time                     Time   `json:"time"`
+	CurrentWitness           string `json:"current_witness"`
+	LastIrreversibleBlockNum int64  `json:"last_irreversible_block_num"`
+	CurrentSupply            Asset  `json:"current_supply"`
+	CurrentHbdSupply         Asset  `json:"current_hbd_supply"`
+	TotalVestingFundHive     Asset  `json:"total_vesting_fund_hive"`
+	TotalVestingShares       Asset  `json:"total_vesting_shares"`
+	HbdInterestRate          int    `json:"hbd_interest_rate"`
+	HbdPrintRate             int    `json:"hbd_print_rate"`
+}
+
+// GetDynamicGlobalProperties returns the current state of the chain.
+func (c *Client) GetDynamicGlobalProperties() (*DynamicGlobalProperties, error) {
+	var resp *rpc.RPCResponse
+	var err error
+	if c.API == AppbaseAPI {
+		resp, err = c.getAPIData("database_api.get_dynamic_global_properties", struct{}{})
+	} else {
+		resp, err = c.getAccountData("get_dynamic_global_properties")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var out DynamicGlobalProperties
+	if err := resp.GetObject(&out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// VestingPrice returns the HIVE/VESTS price used to convert between HIVE and Hive Power.
+func (p DynamicGlobalProperties) VestingPrice() Price {
+	return Price{Base: p.TotalVestingFundHive, Quote: p.TotalVestingShares}
+}
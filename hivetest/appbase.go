@@ -0,0 +1,242 @@
+package hivetest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	gohive "github.com/nathansenn/go-hive"
+)
+
+// appbaseAccountKeys maps the condenser account fields encoded by gohive.AccountData
+// to the names used by database_api.
+var appbaseAccountKeys = map[string]string{
+	"sbd_balance":                       "hbd_balance",
+	"sbd_seconds":                       "hbd_seconds",
+	"sbd_seconds_last_update":           "hbd_seconds_last_update",
+	"sbd_last_interest_payment":         "hbd_last_interest_payment",
+	"savings_sbd_balance":               "savings_hbd_balance",
+	"savings_sbd_seconds":               "savings_hbd_seconds",
+	"savings_sbd_seconds_last_update":   "savings_hbd_seconds_last_update",
+	"savings_sbd_last_interest_payment": "savings_hbd_last_interest_payment",
+	"reward_sbd_balance":                "reward_hbd_balance",
+	"reward_steem_balance":              "reward_hive_balance",
+	"reward_vesting_steem":              "reward_vesting_hive",
+	"down_vote_manabar":                 "downvote_manabar",
+	"next_vesting_withdraw":             "next_vesting_withdrawal",
+	"witnesses_vote_for":                "witnesses_voted_for",
+}
+
+func (s *Server) findAccounts(params json.RawMessage) (interface{}, error) {
+	var p struct {
+		Accounts []string `json:"accounts"`
+	}
+	if err := named(params, &p); err != nil {
+		return nil, err
+	}
+
+	out := []interface{}{}
+	for _, acc := range s.lookupAccounts(p.Accounts) {
+		out = append(out, appbaseAccount(acc))
+	}
+	return map[string]interface{}{"accounts": out}, nil
+}
+
+func (s *Server) listAccounts(params json.RawMessage) (interface{}, error) {
+	var p struct {
+		Start json.RawMessage `json:"start"`
+		Limit int             `json:"limit"`
+		Order string          `json:"order"`
+	}
+	if err := named(params, &p); err != nil {
+		return nil, err
+	}
+	if p.Limit > 1000 {
+		return nil, assertion("limit <= 1000")
+	}
+
+	// key returns the sort key of an account for the requested order.
+	var key func(*gohive.AccountData) [2]string
+	switch p.Order {
+	case "by_name":
+		key = func(a *gohive.AccountData) [2]string { return [2]string{a.Name, ""} }
+	case "by_proxy":
+		key = func(a *gohive.AccountData) [2]string { return [2]string{a.Proxy, a.Name} }
+	case "by_next_vesting_withdrawal":
+		key = func(a *gohive.AccountData) [2]string { return [2]string{a.NextVestingWithdraw, a.Name} }
+	case "by_last_post":
+		key = func(a *gohive.AccountData) [2]string { return [2]string{a.LastPost, a.Name} }
+	default:
+		return nil, invalidParams(fmt.Errorf("unknown order %q", p.Order))
+	}
+
+	var start [2]string
+	if p.Order == "by_name" {
+		if err := json.Unmarshal(p.Start, &start[0]); err != nil {
+			return nil, invalidParams(err)
+		}
+	} else {
+		var tuple []string
+		if err := json.Unmarshal(p.Start, &tuple); err != nil || len(tuple) != 2 {
+			return nil, invalidParams(fmt.Errorf("start must be a [value, name] pair for %v", p.Order))
+		}
+		copy(start[:], tuple)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	accs := s.sortedAccounts()
+
+	less := func(a, b [2]string) bool { return a[0] < b[0] || (a[0] == b[0] && a[1] < b[1]) }
+	sort.SliceStable(accs, func(i, j int) bool { return less(key(accs[i]), key(accs[j])) })
+
+	out := []interface{}{}
+	for _, acc := range accs {
+		if len(out) == p.Limit {
+			break
+		}
+		if !less(key(acc), start) {
+			out = append(out, appbaseAccount(*acc))
+		}
+	}
+	return map[string]interface{}{"accounts": out}, nil
+}
+
+func (s *Server) accountHistoryAPIGetAccountHistory(params json.RawMessage) (interface{}, error) {
+	var p struct {
+		Account string `json:"account"`
+		Start   uint64 `json:"start"`
+		Limit   int64  `json:"limit"`
+	}
+	if err := named(params, &p); err != nil {
+		return nil, err
+	}
+
+	start := int64(-1)
+	if p.Start < math.MaxInt64 {
+		start = int64(p.Start)
+	}
+	page, err := s.historyPage(p.Account, start, p.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range page {
+		e := entry[1].(HistoryEntry)
+		entry[1] = map[string]interface{}{
+			"trx_id":       e.TrxID,
+			"block":        e.Block,
+			"trx_in_block": e.TrxInBlock,
+			"op_in_trx":    e.OpInTrx,
+			"virtual_op":   e.VirtualOp,
+			"timestamp":    e.Timestamp,
+			"op":           appbaseOperation(e.Op),
+		}
+	}
+	return map[string]interface{}{"history": page}, nil
+}
+
+func (s *Server) reputationAPIGetAccountReputations(params json.RawMessage) (interface{}, error) {
+	var p struct {
+		AccountLowerBound string `json:"account_lower_bound"`
+		Limit             int    `json:"limit"`
+	}
+	if err := named(params, &p); err != nil {
+		return nil, err
+	}
+
+	reps, err := s.reputations(p.AccountLowerBound, p.Limit)
+	if err != nil {
+		return nil, err
+	}
+	out := []interface{}{}
+	for _, r := range reps {
+		out = append(out, map[string]interface{}{"name": r.Account, "reputation": r.Reputation})
+	}
+	return map[string]interface{}{"reputations": out}, nil
+}
+
+// appbaseAccount encodes acc the way database_api returns accounts.
+func appbaseAccount(acc gohive.AccountData) map[string]interface{} {
+	b, _ := json.Marshal(acc)
+	var legacy map[string]interface{}
+	json.Unmarshal(b, &legacy)
+
+	out := make(map[string]interface{}, len(legacy))
+	for k, v := range legacy {
+		if name, ok := appbaseAccountKeys[k]; ok {
+			k = name
+		}
+		out[k] = appbaseValue(v)
+	}
+	return out
+}
+
+// commentOptionsExtensions names the comment_options extensions by their id.
+var commentOptionsExtensions = []string{"comment_payout_beneficiaries", "allowed_vote_assets"}
+
+// appbaseOperation converts a condenser ["vote", {...}] operation into the
+// appbase {"type": "vote_operation", "value": {...}} form. Like hived, it also
+// writes comment_options extensions as {"type": ..., "value": {...}} objects.
+func appbaseOperation(op []interface{}) interface{} {
+	if len(op) != 2 {
+		return op
+	}
+	name, _ := op[0].(string)
+
+	// Round trip the value so structs become maps the conversions below can walk.
+	var value interface{}
+	b, _ := json.Marshal(op[1])
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	dec.Decode(&value)
+	value = appbaseValue(value)
+
+	if m, ok := value.(map[string]interface{}); ok && name == "comment_options" {
+		exts, _ := m["extensions"].([]interface{})
+		for i, ext := range exts {
+			pair, ok := ext.([]interface{})
+			if !ok || len(pair) != 2 {
+				continue
+			}
+			n, _ := pair[0].(json.Number)
+			id, err := n.Int64()
+			if err != nil || id < 0 || id >= int64(len(commentOptionsExtensions)) {
+				continue
+			}
+			exts[i] = map[string]interface{}{"type": commentOptionsExtensions[id], "value": pair[1]}
+		}
+	}
+	return map[string]interface{}{"type": name + "_operation", "value": value}
+}
+
+// appbaseValue replaces legacy asset strings anywhere in v with NAI objects.
+func appbaseValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case string:
+		if !strings.HasSuffix(t, " "+gohive.HIVE) && !strings.HasSuffix(t, " "+gohive.HBD) && !strings.HasSuffix(t, " "+gohive.VESTS) {
+			return t
+		}
+		a, err := gohive.ParseAsset(t)
+		if err != nil {
+			return t
+		}
+		return a.NAI()
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(t))
+		for k, val := range t {
+			out[k] = appbaseValue(val)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(t))
+		for i, val := range t {
+			out[i] = appbaseValue(val)
+		}
+		return out
+	}
+	return v
+}
@@ -0,0 +1,402 @@
+package hivetest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	gohive "github.com/nathansenn/go-hive"
+)
+
+// maxHistoryLimit is the largest page hived returns from get_account_history.
+const maxHistoryLimit = 1000
+
+// registerDefaults installs the condenser, database, block and broadcast methods.
+func (s *Server) registerDefaults() {
+	s.handlers["get_accounts"] = s.getAccounts
+	s.handlers["get_account_count"] = s.getAccountCount
+	s.handlers["get_account_history"] = s.getAccountHistory
+	s.handlers["lookup_accounts"] = s.lookupAccountNames
+	s.handlers["get_account_reputations"] = s.getAccountReputations
+	s.handlers["get_dynamic_global_properties"] = s.getDynamicGlobalProperties
+	s.handlers["get_config"] = s.getConfig
+	s.handlers["get_block"] = s.getBlock
+	s.handlers["get_block_header"] = s.getBlockHeader
+	s.handlers["broadcast_transaction"] = s.broadcastTransaction
+	s.handlers["broadcast_transaction_synchronous"] = s.broadcastTransactionSynchronous
+
+	s.handlers["database_api.find_accounts"] = s.findAccounts
+	s.handlers["database_api.list_accounts"] = s.listAccounts
+	s.handlers["account_history_api.get_account_history"] = s.accountHistoryAPIGetAccountHistory
+	s.handlers["reputation_api.get_account_reputations"] = s.reputationAPIGetAccountReputations
+	s.handlers["database_api.get_dynamic_global_properties"] = s.getDynamicGlobalProperties
+	s.handlers["database_api.get_config"] = s.getConfig
+	s.handlers["block_api.get_block"] = s.blockAPIGetBlock
+	s.handlers["block_api.get_block_header"] = s.blockAPIGetBlockHeader
+	s.handlers["network_broadcast_api.broadcast_transaction"] = s.networkBroadcastTransaction
+}
+
+// positional decodes an array of params into out, in order.
+// Missing trailing params leave their targets untouched.
+func positional(params json.RawMessage, out ...interface{}) error {
+	var arr []json.RawMessage
+	if err := json.Unmarshal(params, &arr); err != nil {
+		return invalidParams(err)
+	}
+	if len(arr) > len(out) {
+		return invalidParams(fmt.Errorf("expected at most %d params, got %d", len(out), len(arr)))
+	}
+	for i, raw := range arr {
+		if err := json.Unmarshal(raw, out[i]); err != nil {
+			return invalidParams(err)
+		}
+	}
+	return nil
+}
+
+// named decodes an object of params into out.
+func named(params json.RawMessage, out interface{}) error {
+	if err := json.Unmarshal(params, out); err != nil {
+		return invalidParams(err)
+	}
+	return nil
+}
+
+func (s *Server) getAccounts(params json.RawMessage) (interface{}, error) {
+	var names []string
+	if err := positional(params, &names); err != nil {
+		return nil, err
+	}
+	return s.lookupAccounts(names), nil
+}
+
+func (s *Server) lookupAccounts(names []string) []gohive.AccountData {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := []gohive.AccountData{}
+	for _, name := range names {
+		if acc, ok := s.accounts[name]; ok {
+			out = append(out, *acc)
+		}
+	}
+	return out
+}
+
+func (s *Server) lookupAccountNames(params json.RawMessage) (interface{}, error) {
+	var lowerBound string
+	var limit int
+	if err := positional(params, &lowerBound, &limit); err != nil {
+		return nil, err
+	}
+	if limit > 1000 {
+		return nil, assertion("limit <= 1000")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := []string{}
+	for _, acc := range s.sortedAccounts() {
+		if len(out) == limit {
+			break
+		}
+		if acc.Name >= lowerBound {
+			out = append(out, acc.Name)
+		}
+	}
+	return out, nil
+}
+
+func (s *Server) getAccountCount(params json.RawMessage) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.accounts), nil
+}
+
+func (s *Server) getAccountHistory(params json.RawMessage) (interface{}, error) {
+	var account string
+	var start, limit int64
+	if err := positional(params, &account, &start, &limit); err != nil {
+		return nil, err
+	}
+	return s.historyPage(account, start, limit)
+}
+
+// historyPage returns up to limit history entries of account, ending at start.
+// A start of -1 means the latest entry.
+func (s *Server) historyPage(account string, start, limit int64) ([][]interface{}, error) {
+	if limit > maxHistoryLimit {
+		return nil, assertion(fmt.Sprintf("limit of %d is greater than maximum allowed", limit))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries := s.history[account]
+	last := int64(len(entries)) - 1
+	if start == -1 || start > last {
+		start = last
+	}
+
+	out := [][]interface{}{}
+	first := start - limit + 1
+	if first < 0 {
+		first = 0
+	}
+	for i := first; i <= start; i++ {
+		out = append(out, []interface{}{i, entries[i]})
+	}
+	return out, nil
+}
+
+func (s *Server) getAccountReputations(params json.RawMessage) (interface{}, error) {
+	var lowerBound string
+	var limit int
+	if err := positional(params, &lowerBound, &limit); err != nil {
+		return nil, err
+	}
+	return s.reputations(lowerBound, limit)
+}
+
+// reputations returns the reputation of up to limit accounts from lowerBound on.
+func (s *Server) reputations(lowerBound string, limit int) ([]gohive.AccountReputation, error) {
+	if limit > 1000 {
+		return nil, assertion("limit <= 1000")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := []gohive.AccountReputation{}
+	for _, acc := range s.sortedAccounts() {
+		if len(out) == limit {
+			break
+		}
+		if acc.Name < lowerBound {
+			continue
+		}
+		rep := acc.Reputation
+		if rep == "" {
+			rep = json.Number("0")
+		}
+		out = append(out, gohive.AccountReputation{Account: acc.Name, Reputation: rep})
+	}
+	return out, nil
+}
+
+func (s *Server) getDynamicGlobalProperties(params json.RawMessage) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return copyMap(s.props), nil
+}
+
+func (s *Server) getConfig(params json.RawMessage) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return copyMap(s.config), nil
+}
+
+func (s *Server) getBlock(params json.RawMessage) (interface{}, error) {
+	var num int64
+	if err := positional(params, &num); err != nil {
+		return nil, err
+	}
+	b, ok := s.block(num)
+	if !ok {
+		return nil, nil
+	}
+	return b, nil
+}
+
+func (s *Server) getBlockHeader(params json.RawMessage) (interface{}, error) {
+	var num int64
+	if err := positional(params, &num); err != nil {
+		return nil, err
+	}
+	b, ok := s.block(num)
+	if !ok {
+		return nil, nil
+	}
+	return header(b), nil
+}
+
+func (s *Server) block(num int64) (Block, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if num < 1 || num > int64(len(s.blocks)) {
+		return Block{}, false
+	}
+	return s.blocks[num-1], true
+}
+
+func header(b Block) map[string]interface{} {
+	return map[string]interface{}{
+		"previous":                b.Previous,
+		"timestamp":               b.Timestamp,
+		"witness":                 b.Witness,
+		"transaction_merkle_root": b.TransactionMerkleRoot,
+		"extensions":              b.Extensions,
+	}
+}
+
+func (s *Server) broadcastTransaction(params json.RawMessage) (interface{}, error) {
+	var tx Transaction
+	if err := positional(params, &tx); err != nil {
+		return nil, err
+	}
+	if err := s.accept(tx); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+// broadcastTransactionSynchronous includes the transaction in a new block right away.
+func (s *Server) broadcastTransactionSynchronous(params json.RawMessage) (interface{}, error) {
+	var tx Transaction
+	if err := positional(params, &tx); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.acceptLocked(tx); err != nil {
+		return nil, err
+	}
+	trxNum := len(s.pending) - 1
+	b := s.produceBlock()
+	return map[string]interface{}{
+		"id":        b.TransactionIDs[trxNum],
+		"block_num": len(s.blocks),
+		"trx_num":   trxNum,
+		"expired":   false,
+	}, nil
+}
+
+func (s *Server) networkBroadcastTransaction(params json.RawMessage) (interface{}, error) {
+	var p struct {
+		Trx Transaction `json:"trx"`
+	}
+	if err := named(params, &p); err != nil {
+		return nil, err
+	}
+	if err := s.accept(p.Trx); err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{}, nil
+}
+
+// accept checks tx and queues it for the next block. Signatures are not verified.
+func (s *Server) accept(tx Transaction) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.acceptLocked(tx)
+}
+
+func (s *Server) acceptLocked(tx Transaction) error {
+	if len(tx.Operations) == 0 {
+		return assertion("trx.operations.size() > 0: A transaction must have at least one operation")
+	}
+
+	exp, err := parseTime(tx.Expiration)
+	if err != nil {
+		return invalidParams(errors.New("invalid expiration " + tx.Expiration))
+	}
+	if !exp.After(s.now) {
+		return assertion(fmt.Sprintf("now < trx.expiration: now = %v, trx.exp = %v", s.now.Format(gohive.TimeLayout), tx.Expiration))
+	}
+	s.pending = append(s.pending, tx)
+	return nil
+}
+
+func (s *Server) blockAPIGetBlock(params json.RawMessage) (interface{}, error) {
+	var p struct {
+		BlockNum int64 `json:"block_num"`
+	}
+	if err := named(params, &p); err != nil {
+		return nil, err
+	}
+	b, ok := s.block(p.BlockNum)
+	if !ok {
+		return map[string]interface{}{}, nil
+	}
+	return map[string]interface{}{"block": b}, nil
+}
+
+func (s *Server) blockAPIGetBlockHeader(params json.RawMessage) (interface{}, error) {
+	var p struct {
+		BlockNum int64 `json:"block_num"`
+	}
+	if err := named(params, &p); err != nil {
+		return nil, err
+	}
+	b, ok := s.block(p.BlockNum)
+	if !ok {
+		return map[string]interface{}{}, nil
+	}
+	return map[string]interface{}{"header": header(b)}, nil
+}
+
+// blockID builds a block id which, like hived, starts with the block number.
+func blockID(num int64, seed string) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%d:%s", num, seed)))
+	return fmt.Sprintf("%08x", num) + hex.EncodeToString(sum[:16])
+}
+
+// transactionID hashes the JSON form of tx into a 40 character id.
+func transactionID(tx Transaction) string {
+	b, _ := json.Marshal(tx)
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:20])
+}
+
+// accountFields are the operation fields naming accounts whose history includes the operation.
+var accountFields = []string{
+	"account", "author", "voter", "from", "to", "owner", "delegator", "delegatee",
+	"creator", "receiver", "follower", "following", "new_account_name", "witness", "curator",
+}
+
+// impactedAccounts returns the accounts named by an operation body.
+func impactedAccounts(body interface{}) []string {
+	fields, ok := body.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	seen := map[string]bool{}
+	var out []string
+	add := func(v interface{}) {
+		if name, ok := v.(string); ok && name != "" && !seen[name] {
+			seen[name] = true
+			out = append(out, name)
+		}
+	}
+
+	for _, f := range accountFields {
+		add(fields[f])
+	}
+	for _, f := range []string{"required_auths", "required_posting_auths"} {
+		if names, ok := fields[f].([]interface{}); ok {
+			for _, n := range names {
+				add(n)
+			}
+		}
+	}
+	return out
+}
+
+// parseTime converts a Hive timestamp into a time.Time.
+func parseTime(s string) (time.Time, error) {
+	return time.ParseInLocation(gohive.TimeLayout, s, time.UTC)
+}
+
+func copyMap(m map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
@@ -0,0 +1,417 @@
+// Package hivetest provides an in-process Hive node for integration tests.
+// The node speaks JSON-RPC over HTTP and keeps its chain state in memory.
+// Example:
+// s := hivetest.NewServer()
+// defer s.Close()
+// s.AddAccount(gohive.AccountData{Name: "jrswab", Balance: "1.000 HIVE"})
+//
+// c := gohive.NewClient(s.URL)
+// accs, err := c.GetAccounts("jrswab")
+package hivetest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	gohive "github.com/nathansenn/go-hive"
+	rpc "github.com/ybbus/jsonrpc"
+)
+
+// JSON-RPC error codes returned by the server, matching the ones used by hived.
+const (
+	CodeParseError     = -32700
+	CodeInvalidRequest = -32600
+	CodeMethodNotFound = -32601
+	CodeInvalidParams  = -32602
+	CodeAssertion      = -32000
+)
+
+// HandlerFunc answers a JSON-RPC method. params holds the raw params of the request.
+// Returning an *rpc.RPCError sends it as is, any other error becomes an assertion error.
+type HandlerFunc func(params json.RawMessage) (interface{}, error)
+
+// HistoryEntry is an operation in the history of an account.
+type HistoryEntry struct {
+	TrxID      string        `json:"trx_id"`
+	Block      int64         `json:"block"`
+	TrxInBlock int           `json:"trx_in_block"`
+	OpInTrx    int           `json:"op_in_trx"`
+	VirtualOp  int           `json:"virtual_op"`
+	Timestamp  string        `json:"timestamp"`
+	Op         []interface{} `json:"op"`
+}
+
+// Transaction is a transaction broadcast to the server.
+type Transaction struct {
+	RefBlockNum    int               `json:"ref_block_num"`
+	RefBlockPrefix uint32            `json:"ref_block_prefix"`
+	Expiration     string            `json:"expiration"`
+	Operations     []json.RawMessage `json:"operations"`
+	Extensions     []interface{}     `json:"extensions"`
+	Signatures     []string          `json:"signatures"`
+}
+
+// Block is a block produced by the server.
+type Block struct {
+	Previous              string        `json:"previous"`
+	Timestamp             string        `json:"timestamp"`
+	Witness               string        `json:"witness"`
+	TransactionMerkleRoot string        `json:"transaction_merkle_root"`
+	Extensions            []interface{} `json:"extensions"`
+	WitnessSignature      string        `json:"witness_signature"`
+	Transactions          []Transaction `json:"transactions"`
+	BlockID               string        `json:"block_id"`
+	SigningKey            string        `json:"signing_key"`
+	TransactionIDs        []string      `json:"transaction_ids"`
+}
+
+// Server is a fake Hive node. Its state is changed through the Add and Set methods,
+// by ProduceBlock and by broadcast transactions.
+type Server struct {
+	*httptest.Server
+
+	// Witness signs every produced block.
+	Witness string
+
+	mu       sync.Mutex
+	handlers map[string]HandlerFunc
+	accounts map[string]*gohive.AccountData
+	history  map[string][]HistoryEntry
+	blocks   []Block
+	pending  []Transaction
+	props    map[string]interface{}
+	config   map[string]interface{}
+	now      time.Time
+}
+
+// NewServer starts a Server with an empty chain whose clock starts at the current time.
+func NewServer() *Server {
+	s := &Server{
+		Witness:  "initminer",
+		handlers: make(map[string]HandlerFunc),
+		accounts: make(map[string]*gohive.AccountData),
+		history:  make(map[string][]HistoryEntry),
+		now:      time.Now().UTC().Truncate(3 * time.Second),
+		config: map[string]interface{}{
+			"HIVE_BLOCK_INTERVAL":                   3,
+			"HIVE_CHAIN_ID":                         "beeab0de00000000000000000000000000000000000000000000000000000000",
+			"HIVE_ADDRESS_PREFIX":                   "STM",
+			"HIVE_100_PERCENT":                      10000,
+			"HIVE_VOTING_MANA_REGENERATION_SECONDS": 432000,
+		},
+	}
+	s.props = map[string]interface{}{
+		"head_block_number":           0,
+		"head_block_id":               blockID(0, ""),
+		"time":                        s.now.Format(gohive.TimeLayout),
+		"current_witness":             s.Witness,
+		"last_irreversible_block_num": 0,
+		"current_supply":              "400000000.000 HIVE",
+		"current_hbd_supply":          "30000000.000 HBD",
+		"total_vesting_fund_hive":     "180000000.000 HIVE",
+		"total_vesting_shares":        "320000000000.000000 VESTS",
+		"hbd_interest_rate":           2000,
+		"hbd_print_rate":              10000,
+	}
+	s.registerDefaults()
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Handle registers fn for method, replacing any existing handler.
+// Condenser methods are registered without the condenser_api prefix.
+func (s *Server) Handle(method string, fn HandlerFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[method] = fn
+}
+
+// AddAccount adds acc to the chain, replacing an account with the same name.
+// A zero ID is replaced with the next free one.
+func (s *Server) AddAccount(acc gohive.AccountData) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if old, ok := s.accounts[acc.Name]; ok && acc.ID == 0 {
+		acc.ID = old.ID
+	}
+	if acc.ID == 0 {
+		acc.ID = len(s.accounts)
+	}
+	if acc.Created == "" {
+		acc.Created = s.now.Format(gohive.TimeLayout)
+	}
+	s.accounts[acc.Name] = &acc
+}
+
+// UpdateAccount calls fn with the stored account called name.
+func (s *Server) UpdateAccount(name string, fn func(*gohive.AccountData)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	acc, ok := s.accounts[name]
+	if !ok {
+		return fmt.Errorf("unknown account %v", name)
+	}
+	fn(acc)
+	return nil
+}
+
+// Account returns a copy of the stored account called name.
+func (s *Server) Account(name string) (gohive.AccountData, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	acc, ok := s.accounts[name]
+	if !ok {
+		return gohive.AccountData{}, false
+	}
+	return *acc, true
+}
+
+// AddHistory appends an operation to the history of account at the current head block.
+func (s *Server) AddHistory(account, opName string, op interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.addHistory(account, HistoryEntry{
+		TrxID:     strings.Repeat("0", 40),
+		Block:     int64(len(s.blocks)),
+		Timestamp: s.now.Format(gohive.TimeLayout),
+		Op:        []interface{}{opName, op},
+	})
+}
+
+// SetProperty sets a field returned by get_dynamic_global_properties.
+func (s *Server) SetProperty(key string, value interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.props[key] = value
+}
+
+// SetConfig sets a field returned by get_config.
+func (s *Server) SetConfig(key string, value interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.config[key] = value
+}
+
+// SetTime sets the time of the next produced block.
+func (s *Server) SetTime(t time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.now = t.UTC().Truncate(time.Second)
+	s.props["time"] = s.now.Format(gohive.TimeLayout)
+}
+
+// ProduceBlock puts every pending transaction into a new block and returns it.
+// Blocks become irreversible as soon as they are produced.
+func (s *Server) ProduceBlock() Block {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.produceBlock()
+}
+
+// Blocks returns every produced block, starting at block 1.
+func (s *Server) Blocks() []Block {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Block(nil), s.blocks...)
+}
+
+// Pending returns the broadcast transactions waiting for the next block.
+func (s *Server) Pending() []Transaction {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Transaction(nil), s.pending...)
+}
+
+func (s *Server) produceBlock() Block {
+	num := int64(len(s.blocks) + 1)
+	s.now = s.now.Add(3 * time.Second)
+	timestamp := s.now.Format(gohive.TimeLayout)
+
+	prev := blockID(0, "")
+	if len(s.blocks) > 0 {
+		prev = s.blocks[len(s.blocks)-1].BlockID
+	}
+
+	b := Block{
+		Previous:              prev,
+		Timestamp:             timestamp,
+		Witness:               s.Witness,
+		TransactionMerkleRoot: strings.Repeat("0", 40),
+		Extensions:            []interface{}{},
+		WitnessSignature:      strings.Repeat("0", 130),
+		Transactions:          s.pending,
+		SigningKey:            "STM1111111111111111111111111111111114T1Anm",
+		TransactionIDs:        []string{},
+	}
+	if b.Transactions == nil {
+		b.Transactions = []Transaction{}
+	}
+	s.pending = nil
+
+	for i, tx := range b.Transactions {
+		id := transactionID(tx)
+		b.TransactionIDs = append(b.TransactionIDs, id)
+		for j, raw := range tx.Operations {
+			var op []interface{}
+			if err := json.Unmarshal(raw, &op); err != nil || len(op) != 2 {
+				continue
+			}
+			for _, acc := range impactedAccounts(op[1]) {
+				s.addHistory(acc, HistoryEntry{
+					TrxID:      id,
+					Block:      num,
+					TrxInBlock: i,
+					OpInTrx:    j,
+					Timestamp:  timestamp,
+					Op:         op,
+				})
+			}
+		}
+	}
+	b.BlockID = blockID(num, timestamp)
+	s.blocks = append(s.blocks, b)
+
+	s.props["head_block_number"] = num
+	s.props["head_block_id"] = b.BlockID
+	s.props["time"] = timestamp
+	s.props["current_witness"] = s.Witness
+	s.props["last_irreversible_block_num"] = num
+	return b
+}
+
+func (s *Server) addHistory(account string, e HistoryEntry) {
+	s.history[account] = append(s.history[account], e)
+}
+
+// serveHTTP answers single and batch JSON-RPC requests.
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	var body json.RawMessage
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeJSON(w, errorResponse(0, &rpc.RPCError{Code: CodeParseError, Message: "Parse Error: " + err.Error()}))
+		return
+	}
+
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '[' {
+		var reqs []json.RawMessage
+		if err := json.Unmarshal(body, &reqs); err != nil {
+			writeJSON(w, errorResponse(0, &rpc.RPCError{Code: CodeParseError, Message: "Parse Error: " + err.Error()}))
+			return
+		}
+		out := make([]*rpc.RPCResponse, 0, len(reqs))
+		for _, req := range reqs {
+			out = append(out, s.serveRequest(req))
+		}
+		writeJSON(w, out)
+		return
+	}
+	writeJSON(w, s.serveRequest(body))
+}
+
+// serveRequest answers a single JSON-RPC request.
+func (s *Server) serveRequest(body json.RawMessage) *rpc.RPCResponse {
+	var req struct {
+		JSONRPC string          `json:"jsonrpc"`
+		Method  string          `json:"method"`
+		Params  json.RawMessage `json:"params"`
+		ID      int             `json:"id"`
+	}
+	if err := json.Unmarshal(body, &req); err != nil || req.JSONRPC != "2.0" || req.Method == "" {
+		return errorResponse(req.ID, &rpc.RPCError{Code: CodeInvalidRequest, Message: "Invalid Request"})
+	}
+
+	method, params, err := resolveMethod(req.Method, req.Params)
+	if err != nil {
+		return errorResponse(req.ID, err)
+	}
+
+	s.mu.Lock()
+	fn, ok := s.handlers[method]
+	s.mu.Unlock()
+	if !ok {
+		return errorResponse(req.ID, &rpc.RPCError{Code: CodeMethodNotFound, Message: "Could not find method " + method})
+	}
+
+	// hived answers null params with an error, so a client must send [] or {}.
+	if bytes.Equal(bytes.TrimSpace(params), []byte("null")) {
+		return errorResponse(req.ID, invalidParams(fmt.Errorf("params must be an array or object, not null")))
+	}
+	if len(params) == 0 {
+		params = json.RawMessage("[]")
+	}
+	result, herr := fn(params)
+	if herr != nil {
+		return errorResponse(req.ID, herr)
+	}
+	return &rpc.RPCResponse{JSONRPC: "2.0", Result: result, ID: req.ID}
+}
+
+// resolveMethod unwraps "call" requests and drops the condenser_api namespace.
+func resolveMethod(method string, params json.RawMessage) (string, json.RawMessage, *rpc.RPCError) {
+	if method == "call" {
+		var call []json.RawMessage
+		if err := json.Unmarshal(params, &call); err != nil || len(call) < 2 {
+			return "", nil, &rpc.RPCError{Code: CodeInvalidParams, Message: "call requires api, method and params"}
+		}
+		var api, name string
+		if json.Unmarshal(call[0], &api) != nil || json.Unmarshal(call[1], &name) != nil {
+			return "", nil, &rpc.RPCError{Code: CodeInvalidParams, Message: "call requires api, method and params"}
+		}
+		method = api + "." + name
+		params = nil
+		if len(call) > 2 {
+			params = call[2]
+		}
+	}
+	return strings.TrimPrefix(method, "condenser_api."), params, nil
+}
+
+func errorResponse(id int, err error) *rpc.RPCResponse {
+	rerr, ok := err.(*rpc.RPCError)
+	if !ok {
+		rerr = assertion(err.Error())
+	}
+	return &rpc.RPCResponse{JSONRPC: "2.0", Error: rerr, ID: id}
+}
+
+// assertion builds the error hived returns for a failed FC_ASSERT.
+func assertion(msg string) *rpc.RPCError {
+	return &rpc.RPCError{
+		Code:    CodeAssertion,
+		Message: "Assert Exception:" + msg,
+		Data: map[string]interface{}{
+			"code":    10,
+			"name":    "assert_exception",
+			"message": "Assert Exception",
+		},
+	}
+}
+
+// invalidParams builds the error returned for params of the wrong shape.
+func invalidParams(err error) *rpc.RPCError {
+	return &rpc.RPCError{Code: CodeInvalidParams, Message: "Invalid parameters: " + err.Error()}
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+// sortedAccounts returns the stored accounts ordered by name.
+func (s *Server) sortedAccounts() []*gohive.AccountData {
+	out := make([]*gohive.AccountData, 0, len(s.accounts))
+	for _, acc := range s.accounts {
+		out = append(out, acc)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
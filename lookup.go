@@ -0,0 +1,195 @@
+package gohive
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AccountOrder is the sort order used by ListAccounts.
+type AccountOrder string
+
+// Orders accepted by database_api.list_accounts.
+const (
+	AccountsByName                  AccountOrder = "by_name"
+	AccountsByProxy                 AccountOrder = "by_proxy"
+	AccountsByNextVestingWithdrawal AccountOrder = "by_next_vesting_withdrawal"
+	AccountsByLastPost              AccountOrder = "by_last_post"
+)
+
+// minTime is the earliest timestamp, used as the first cursor of time ordered lists.
+const minTime = "1970-01-01T00:00:00"
+
+// maxListLimit is the largest page the list and lookup methods return.
+const maxListLimit = 1000
+
+// LookupAccounts returns up to limit account names starting with prefix, in alphabetical order.
+// Uses database_api.list_accounts when the Client uses AppbaseAPI.
+func (c *Client) LookupAccounts(prefix string, limit int) ([]string, error) {
+	if limit < 1 || limit > maxListLimit {
+		return nil, fmt.Errorf("method LookupAccounts needs a limit between 1 and %d", maxListLimit)
+	}
+
+	var names []string
+	if c.API == AppbaseAPI {
+		var err error
+		if names, err = c.lookupAccountsAppbase(prefix, limit); err != nil {
+			return nil, err
+		}
+	} else {
+		resp, err := c.getAccountData("lookup_accounts", prefix, limit)
+		if err != nil {
+			return nil, err
+		}
+		names = []string{}
+		if err := resp.GetObject(&names); err != nil {
+			return nil, err
+		}
+	}
+
+	out := []string{}
+	for _, name := range names {
+		if !strings.HasPrefix(name, prefix) {
+			break
+		}
+		out = append(out, name)
+	}
+	return out, nil
+}
+
+// AccountCursor returns the start value which makes ListAccounts begin at acc.
+func AccountCursor(order AccountOrder, acc AccountData) interface{} {
+	switch order {
+	case AccountsByProxy:
+		return []string{acc.Proxy, acc.Name}
+	case AccountsByNextVestingWithdrawal:
+		return []string{acc.NextVestingWithdraw, acc.Name}
+	case AccountsByLastPost:
+		return []string{acc.LastPost, acc.Name}
+	default:
+		return acc.Name
+	}
+}
+
+// firstAccountCursor returns the start value for the beginning of order.
+func firstAccountCursor(order AccountOrder) interface{} {
+	switch order {
+	case AccountsByProxy:
+		return []string{"", ""}
+	case AccountsByNextVestingWithdrawal, AccountsByLastPost:
+		return []string{minTime, ""}
+	default:
+		return ""
+	}
+}
+
+// ListAccounts returns up to limit accounts in order, starting at start, using database_api.list_accounts.
+// start is a name for AccountsByName and a [value, name] pair for the other orders;
+// use AccountCursor to build it from an account. A nil start begins at the first account.
+func (c *Client) ListAccounts(start interface{}, limit int, order AccountOrder) (*[]AccountData, error) {
+	if limit < 1 || limit > maxListLimit {
+		return nil, fmt.Errorf("method ListAccounts needs a limit between 1 and %d", maxListLimit)
+	}
+	if start == nil {
+		start = firstAccountCursor(order)
+	}
+
+	resp, err := c.getAPIData("database_api.list_accounts", struct {
+		Start interface{}  `json:"start"`
+		Limit int          `json:"limit"`
+		Order AccountOrder `json:"order"`
+	}{start, limit, order})
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Accounts []map[string]interface{} `json:"accounts"`
+	}
+	if err := decodeResult(resp, &result); err != nil {
+		return nil, err
+	}
+	return legacyAccounts(result.Accounts)
+}
+
+// AccountIterator walks through every account with ListAccounts, one page at a time.
+// Example:
+//
+//	it := c.IterateAccounts(AccountsByName, 1000)
+//	for it.Next() {
+//		fmt.Println(it.Account().Name)
+//	}
+//	if err := it.Err(); err != nil {
+//		fmt.Println(err)
+//	}
+type AccountIterator struct {
+	c        *Client
+	order    AccountOrder
+	pageSize int
+	cursor   interface{}
+	page     []AccountData
+	pos      int
+	done     bool
+	err      error
+}
+
+// IterateAccounts returns an AccountIterator fetching pageSize accounts per call.
+// Pages overlap by one account, so pageSize is raised to at least 2, and it is
+// lowered to the node limit of 1000.
+func (c *Client) IterateAccounts(order AccountOrder, pageSize int) *AccountIterator {
+	if pageSize < 2 {
+		pageSize = 2
+	}
+	if pageSize > maxListLimit {
+		pageSize = maxListLimit
+	}
+	return &AccountIterator{c: c, order: order, pageSize: pageSize, pos: -1}
+}
+
+// Next advances to the next account, fetching a new page when needed.
+// It returns false when there are no accounts left or a call failed.
+func (it *AccountIterator) Next() bool {
+	it.pos++
+	if it.pos < len(it.page) {
+		return true
+	}
+	if it.done || it.err != nil {
+		return false
+	}
+
+	accs, err := it.c.ListAccounts(it.cursor, it.pageSize, it.order)
+	if err != nil {
+		it.err = err
+		return false
+	}
+
+	page := *accs
+	if len(page) < it.pageSize {
+		it.done = true
+	}
+	// The cursor is inclusive, so later pages start with the last account of the previous one.
+	if len(it.page) > 0 && len(page) > 0 && page[0].Name == it.page[len(it.page)-1].Name {
+		page = page[1:]
+	}
+	if len(page) == 0 {
+		it.done = true
+		return false
+	}
+
+	it.cursor = AccountCursor(it.order, page[len(page)-1])
+	it.page = page
+	it.pos = 0
+	return true
+}
+
+// Account returns the current account.
+func (it *AccountIterator) Account() AccountData {
+	if it.pos < 0 || it.pos >= len(it.page) {
+		return AccountData{}
+	}
+	return it.page[it.pos]
+}
+
+// Err returns the error which stopped the iteration, if any.
+func (it *AccountIterator) Err() error {
+	return it.err
+}
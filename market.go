@@ -0,0 +1,208 @@
+package gohive
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// maxOrderBookLimit is the largest order book side get_order_book returns.
+const maxOrderBookLimit = 500
+
+// Order is an order in the internal market order book.
+// Hive and Hbd are amounts in the smallest unit of each asset. They are
+// json.Number because nodes may send share_type values as strings.
+type Order struct {
+	OrderPrice Price       `json:"order_price"`
+	RealPrice  json.Number `json:"real_price"`
+	Hive       json.Number `json:"hive"`
+	Hbd        json.Number `json:"hbd"`
+	Created    Time        `json:"created"`
+}
+
+// OrderBook holds the best bids and asks of the internal market.
+type OrderBook struct {
+	Bids []Order `json:"bids"`
+	Asks []Order `json:"asks"`
+}
+
+// Ticker summarises the internal market over the last 24 hours.
+type Ticker struct {
+	Latest        json.Number `json:"latest"`
+	LowestAsk     json.Number `json:"lowest_ask"`
+	HighestBid    json.Number `json:"highest_bid"`
+	PercentChange json.Number `json:"percent_change"`
+	HiveVolume    Asset       `json:"hive_volume"`
+	HbdVolume     Asset       `json:"hbd_volume"`
+}
+
+// Volume is the internal market volume over the last 24 hours.
+type Volume struct {
+	HiveVolume Asset `json:"hive_volume"`
+	HbdVolume  Asset `json:"hbd_volume"`
+}
+
+// Trade is a filled order of the internal market.
+type Trade struct {
+	Date        Time  `json:"date"`
+	CurrentPays Asset `json:"current_pays"`
+	OpenPays    Asset `json:"open_pays"`
+}
+
+// BucketPrices holds the prices and volume of one asset in a market history bucket.
+// All values are in the smallest unit of the asset, as numbers or numeric strings.
+type BucketPrices struct {
+	High   json.Number `json:"high"`
+	Low    json.Number `json:"low"`
+	Open   json.Number `json:"open"`
+	Close  json.Number `json:"close"`
+	Volume json.Number `json:"volume"`
+}
+
+// MarketBucket is one candle of the internal market history.
+type MarketBucket struct {
+	ID      int64        `json:"id"`
+	Open    Time         `json:"open"`
+	Seconds int          `json:"seconds"`
+	Hive    BucketPrices `json:"hive"`
+	NonHive BucketPrices `json:"non_hive"`
+}
+
+// OpenOrder is an unfilled limit order of an account.
+// ForSale is in the smallest unit of the asset being sold, as a number or numeric string.
+type OpenOrder struct {
+	ID         int64       `json:"id"`
+	Created    Time        `json:"created"`
+	Expiration Time        `json:"expiration"`
+	Seller     string      `json:"seller"`
+	OrderID    uint32      `json:"orderid"`
+	ForSale    json.Number `json:"for_sale"`
+	SellPrice  Price       `json:"sell_price"`
+	RealPrice  json.Number `json:"real_price"`
+	Rewarded   bool        `json:"rewarded"`
+}
+
+// GetOrderBook returns up to limit bids and asks of the internal market.
+func (c *Client) GetOrderBook(limit int) (*OrderBook, error) {
+	if limit < 1 || limit > maxOrderBookLimit {
+		return nil, fmt.Errorf("method GetOrderBook needs a limit between 1 and %d", maxOrderBookLimit)
+	}
+
+	resp, err := c.getAccountData("get_order_book", limit)
+	if err != nil {
+		return nil, err
+	}
+
+	out := OrderBook{Bids: []Order{}, Asks: []Order{}}
+	if err := resp.GetObject(&out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetTicker returns the internal market ticker.
+func (c *Client) GetTicker() (*Ticker, error) {
+	resp, err := c.getAccountData("get_ticker")
+	if err != nil {
+		return nil, err
+	}
+
+	var out Ticker
+	if err := resp.GetObject(&out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetVolume returns the internal market volume.
+func (c *Client) GetVolume() (*Volume, error) {
+	resp, err := c.getAccountData("get_volume")
+	if err != nil {
+		return nil, err
+	}
+
+	var out Volume
+	if err := resp.GetObject(&out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetTradeHistory returns up to limit trades made between start and end.
+func (c *Client) GetTradeHistory(start, end time.Time, limit int) ([]Trade, error) {
+	if limit < 1 || limit > maxListLimit {
+		return nil, fmt.Errorf("method GetTradeHistory needs a limit between 1 and %d", maxListLimit)
+	}
+
+	resp, err := c.getAccountData("get_trade_history", Time{start}, Time{end}, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	out := []Trade{}
+	if err := resp.GetObject(&out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetRecentTrades returns the last limit trades of the internal market, newest first.
+func (c *Client) GetRecentTrades(limit int) ([]Trade, error) {
+	if limit < 1 || limit > maxListLimit {
+		return nil, fmt.Errorf("method GetRecentTrades needs a limit between 1 and %d", maxListLimit)
+	}
+
+	resp, err := c.getAccountData("get_recent_trades", limit)
+	if err != nil {
+		return nil, err
+	}
+
+	out := []Trade{}
+	if err := resp.GetObject(&out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetMarketHistory returns the market history between start and end in buckets of
+// bucketSeconds, which must be one of the sizes returned by GetMarketHistoryBuckets.
+func (c *Client) GetMarketHistory(bucketSeconds int, start, end time.Time) ([]MarketBucket, error) {
+	resp, err := c.getAccountData("get_market_history", bucketSeconds, Time{start}, Time{end})
+	if err != nil {
+		return nil, err
+	}
+
+	out := []MarketBucket{}
+	if err := resp.GetObject(&out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetMarketHistoryBuckets returns the bucket sizes, in seconds, tracked by the node.
+func (c *Client) GetMarketHistoryBuckets() ([]int, error) {
+	resp, err := c.getAccountData("get_market_history_buckets")
+	if err != nil {
+		return nil, err
+	}
+
+	out := []int{}
+	if err := resp.GetObject(&out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetOpenOrders returns the unfilled limit orders of account.
+func (c *Client) GetOpenOrders(account string) ([]OpenOrder, error) {
+	resp, err := c.getAccountData("get_open_orders", account)
+	if err != nil {
+		return nil, err
+	}
+
+	out := []OpenOrder{}
+	if err := resp.GetObject(&out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
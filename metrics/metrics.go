@@ -0,0 +1,176 @@
+// Package metrics records Prometheus metrics for calls made through a gohive.Client.
+// Example:
+// m := metrics.New("hive")
+// prometheus.MustRegister(m)
+//
+// c := gohive.NewClient()
+// metrics.Instrument(c, m)
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net"
+	"time"
+
+	gohive "github.com/nathansenn/go-hive"
+	"github.com/prometheus/client_golang/prometheus"
+	rpc "github.com/ybbus/jsonrpc"
+)
+
+// Error classes used for the class label of the errors counter.
+const (
+	ClassTransport      = "transport"
+	ClassTimeout        = "timeout"
+	ClassHTTP           = "http"
+	ClassParse          = "parse"
+	ClassInvalidRequest = "invalid_request"
+	ClassMethodNotFound = "method_not_found"
+	ClassInvalidParams  = "invalid_params"
+	ClassInternal       = "internal"
+	ClassServer         = "server"
+)
+
+// Metrics holds the collectors for RPC calls. It implements prometheus.Collector.
+type Metrics struct {
+	requests  *prometheus.CounterVec
+	latency   *prometheus.HistogramVec
+	errors    *prometheus.CounterVec
+	retries   *prometheus.CounterVec
+	failovers *prometheus.CounterVec
+}
+
+// New creates the collectors using namespace as the metric prefix.
+func New(namespace string) *Metrics {
+	return &Metrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "rpc_requests_total",
+			Help:      "Number of JSON-RPC requests sent to Hive nodes.",
+		}, []string{"method", "node"}),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "rpc_request_duration_seconds",
+			Help:      "Latency of JSON-RPC requests sent to Hive nodes.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "node"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "rpc_errors_total",
+			Help:      "Number of failed JSON-RPC requests by error class.",
+		}, []string{"method", "node", "class"}),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "rpc_retries_total",
+			Help:      "Number of retried JSON-RPC requests.",
+		}, []string{"method", "node"}),
+		failovers: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "rpc_failovers_total",
+			Help:      "Number of JSON-RPC requests moved to another node.",
+		}, []string{"method", "from", "to"}),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (m *Metrics) Describe(ch chan<- *prometheus.Desc) {
+	m.requests.Describe(ch)
+	m.latency.Describe(ch)
+	m.errors.Describe(ch)
+	m.retries.Describe(ch)
+	m.failovers.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (m *Metrics) Collect(ch chan<- prometheus.Metric) {
+	m.requests.Collect(ch)
+	m.latency.Collect(ch)
+	m.errors.Collect(ch)
+	m.retries.Collect(ch)
+	m.failovers.Collect(ch)
+}
+
+// ObserveRetry counts a retry of method against node.
+// Instrument connects it to a gohive.WSCaller; other Callers which retry can call it themselves.
+func (m *Metrics) ObserveRetry(method, node string) {
+	m.retries.WithLabelValues(method, node).Inc()
+}
+
+// ObserveFailover counts a request for method moved from one node to another.
+// Instrument connects it to a gohive.WSCaller with fallback nodes.
+func (m *Metrics) ObserveFailover(method, from, to string) {
+	m.failovers.WithLabelValues(method, from, to).Inc()
+}
+
+// Caller is a gohive.Caller which records metrics for every request.
+type Caller struct {
+	Next    gohive.Caller
+	Node    string
+	Metrics *Metrics
+}
+
+// CallRaw passes req to Next and records its count, latency and error class.
+func (c *Caller) CallRaw(req *rpc.RPCRequest) (*rpc.RPCResponse, error) {
+	return c.CallRawContext(context.Background(), req)
+}
+
+// CallRawContext is CallRaw passing ctx on to Next.
+func (c *Caller) CallRawContext(ctx context.Context, req *rpc.RPCRequest) (*rpc.RPCResponse, error) {
+	start := time.Now()
+	resp, err := gohive.CallContext(ctx, c.Next, req)
+
+	c.Metrics.requests.WithLabelValues(req.Method, c.Node).Inc()
+	c.Metrics.latency.WithLabelValues(req.Method, c.Node).Observe(time.Since(start).Seconds())
+	if class := ErrorClass(resp, err); class != "" {
+		c.Metrics.errors.WithLabelValues(req.Method, c.Node, class).Inc()
+	}
+	return resp, err
+}
+
+// Instrument wraps the Caller of c so every call through c is recorded in m.
+// When the Caller is a gohive.WSCaller its retries and failovers are recorded too.
+func Instrument(c *gohive.Client, m *Metrics) {
+	if ws, ok := c.Client.(*gohive.WSCaller); ok {
+		ws.OnRetry = m.ObserveRetry
+		ws.OnFailover = m.ObserveFailover
+	}
+	c.Client = &Caller{
+		Next:    c.Client,
+		Node:    c.URL,
+		Metrics: m,
+	}
+}
+
+// ErrorClass returns the error class of a call, or an empty string when it succeeded.
+func ErrorClass(resp *rpc.RPCResponse, err error) string {
+	if err != nil {
+		var httpErr *rpc.HTTPError
+		if errors.As(err, &httpErr) {
+			return ClassHTTP
+		}
+		var netErr net.Error
+		if errors.As(err, &netErr) && netErr.Timeout() {
+			return ClassTimeout
+		}
+		return ClassTransport
+	}
+
+	if resp == nil || resp.Error == nil {
+		return ""
+	}
+
+	switch code := resp.Error.Code; {
+	case code == -32700:
+		return ClassParse
+	case code == -32600:
+		return ClassInvalidRequest
+	case code == -32601:
+		return ClassMethodNotFound
+	case code == -32602:
+		return ClassInvalidParams
+	case code == -32603:
+		return ClassInternal
+	default:
+		return ClassServer
+	}
+}
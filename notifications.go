@@ -0,0 +1,159 @@
+package gohive
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// maxNotificationLimit is the largest page bridge.account_notifications returns.
+const maxNotificationLimit = 100
+
+// NotificationPollInterval is the time between two polls of WatchNotifications.
+var NotificationPollInterval = time.Minute
+
+// Notification is a Hivemind notification, such as a vote, reply or mention.
+type Notification struct {
+	ID    int64  `json:"id"`
+	Type  string `json:"type"`
+	Score int    `json:"score"`
+	Date  Time   `json:"date"`
+	Msg   string `json:"msg"`
+	URL   string `json:"url"`
+}
+
+// UnreadCount is the number of notifications received since LastRead.
+type UnreadCount struct {
+	LastRead Time `json:"lastread"`
+	Unread   int  `json:"unread"`
+}
+
+// AccountNotifications returns up to limit notifications of account, newest first,
+// older than the notification lastID. A lastID of 0 starts at the newest one.
+func (c *Client) AccountNotifications(account string, lastID int64, limit int) ([]Notification, error) {
+	if limit < 1 || limit > maxNotificationLimit {
+		return nil, fmt.Errorf("method AccountNotifications needs a limit between 1 and %d", maxNotificationLimit)
+	}
+
+	resp, err := c.getAPIData("bridge.account_notifications", struct {
+		Account string `json:"account"`
+		LastID  int64  `json:"last_id,omitempty"`
+		Limit   int    `json:"limit"`
+	}{account, lastID, limit})
+	if err != nil {
+		return nil, err
+	}
+
+	out := []Notification{}
+	if err := resp.GetObject(&out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UnreadNotifications returns how many notifications account has not read yet.
+func (c *Client) UnreadNotifications(account string) (*UnreadCount, error) {
+	resp, err := c.getAPIData("bridge.unread_notifications", struct {
+		Account string `json:"account"`
+	}{account})
+	if err != nil {
+		return nil, err
+	}
+
+	var out UnreadCount
+	if err := resp.GetObject(&out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// WatchNotifications polls the notifications of account every NotificationPollInterval
+// and sends the ones received after the call, oldest first. Failed polls are sent
+// on the error channel, dropped if it is full, and polling goes on.
+// Both channels are closed once ctx is done.
+func (c *Client) WatchNotifications(ctx context.Context, account string) (<-chan Notification, <-chan error) {
+	out := make(chan Notification)
+	errs := make(chan error, 1)
+	cc := c.WithContext(ctx)
+
+	go func() {
+		defer close(out)
+		defer close(errs)
+
+		lastSeen := int64(-1)
+		ticker := time.NewTicker(NotificationPollInterval)
+		defer ticker.Stop()
+		for {
+			if lastSeen < 0 {
+				// The first poll only records where the new notifications begin.
+				page, err := cc.AccountNotifications(account, 0, 1)
+				if err != nil {
+					sendError(errs, err)
+				} else {
+					lastSeen = 0
+					if len(page) > 0 {
+						lastSeen = page[0].ID
+					}
+				}
+			} else {
+				fresh, err := cc.notificationsAfter(account, lastSeen)
+				if err != nil {
+					sendError(errs, err)
+				}
+				for _, n := range fresh {
+					select {
+					case out <- n:
+					case <-ctx.Done():
+						return
+					}
+					lastSeen = n.ID
+				}
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out, errs
+}
+
+// notificationsAfter returns the notifications of account newer than lastSeen,
+// oldest first, paging back until it reaches lastSeen.
+func (c *Client) notificationsAfter(account string, lastSeen int64) ([]Notification, error) {
+	var fresh []Notification
+	lastID := int64(0)
+	for {
+		page, err := c.AccountNotifications(account, lastID, maxNotificationLimit)
+		if err != nil {
+			return nil, err
+		}
+		done := len(page) < maxNotificationLimit
+		for _, n := range page {
+			if n.ID <= lastSeen {
+				done = true
+				break
+			}
+			fresh = append(fresh, n)
+		}
+		if done || len(page) == 0 {
+			break
+		}
+		lastID = page[len(page)-1].ID
+	}
+
+	for i, j := 0, len(fresh)-1; i < j; i, j = i+1, j-1 {
+		fresh[i], fresh[j] = fresh[j], fresh[i]
+	}
+	return fresh, nil
+}
+
+// sendError sends err on errs unless it is full.
+func sendError(errs chan<- error, err error) {
+	select {
+	case errs <- err:
+	default:
+	}
+}
@@ -0,0 +1,1517 @@
+package gohive
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+	"unicode/utf8"
+)
+
+// VoteOperation votes on a post. Weight is in basis points, negative for downvotes.
+type VoteOperation struct {
+	Voter    string `json:"voter"`
+	Author   string `json:"author"`
+	Permlink string `json:"permlink"`
+	Weight   int16  `json:"weight"`
+}
+
+// NewVote returns a vote by voter on author/permlink at weight.
+func NewVote(voter, author, permlink string, weight int) (VoteOperation, error) {
+	if weight < -percent100 || weight > percent100 {
+		return VoteOperation{}, fmt.Errorf("vote weight %d is not between -%d and %d", weight, percent100, percent100)
+	}
+	op := VoteOperation{Voter: voter, Author: author, Permlink: permlink, Weight: int16(weight)}
+	return op, op.Validate()
+}
+
+// Type returns VoteOp.
+func (op VoteOperation) Type() OpType { return VoteOp }
+
+// Validate checks the names, the permlink and the weight.
+func (op VoteOperation) Validate() error {
+	if err := validateAccounts(op.Voter, op.Author); err != nil {
+		return err
+	}
+	if op.Weight < -percent100 || op.Weight > percent100 {
+		return fmt.Errorf("vote weight %d is not between -%d and %d", op.Weight, percent100, percent100)
+	}
+	return ValidatePermlink(op.Permlink)
+}
+
+func (op VoteOperation) encode(e *encoder) {
+	e.string(op.Voter)
+	e.string(op.Author)
+	e.string(op.Permlink)
+	e.int16(op.Weight)
+}
+
+// CommentOperation creates or edits a post, or a comment when ParentAuthor is set.
+// For posts ParentPermlink is the main tag or community.
+type CommentOperation struct {
+	ParentAuthor   string `json:"parent_author"`
+	ParentPermlink string `json:"parent_permlink"`
+	Author         string `json:"author"`
+	Permlink       string `json:"permlink"`
+	Title          string `json:"title"`
+	Body           string `json:"body"`
+	JSONMetadata   string `json:"json_metadata"`
+}
+
+// NewComment returns a comment by author replying to parentAuthor/parentPermlink.
+// metadata is encoded to JSON and may be nil.
+func NewComment(parentAuthor, parentPermlink, author, permlink, title, body string, metadata interface{}) (CommentOperation, error) {
+	op := CommentOperation{ParentAuthor: parentAuthor, ParentPermlink: parentPermlink, Author: author, Permlink: permlink, Title: title, Body: body}
+	if metadata != nil {
+		b, err := json.Marshal(metadata)
+		if err != nil {
+			return CommentOperation{}, err
+		}
+		op.JSONMetadata = string(b)
+	}
+	return op, op.Validate()
+}
+
+// Type returns CommentOp.
+func (op CommentOperation) Type() OpType { return CommentOp }
+
+// Validate checks the names, permlinks, title length, body and metadata.
+func (op CommentOperation) Validate() error {
+	if op.ParentAuthor != "" {
+		if err := ValidateAccountName(op.ParentAuthor); err != nil {
+			return err
+		}
+	}
+	if err := ValidateAccountName(op.Author); err != nil {
+		return err
+	}
+	if err := ValidatePermlink(op.ParentPermlink); err != nil {
+		return err
+	}
+	if err := ValidatePermlink(op.Permlink); err != nil {
+		return err
+	}
+	if len(op.Title) >= maxTitleLength {
+		return fmt.Errorf("comment title is longer than %d bytes", maxTitleLength-1)
+	}
+	if op.Body == "" {
+		return fmt.Errorf("comment body is empty")
+	}
+	return validateJSON("comment json_metadata", op.JSONMetadata)
+}
+
+func (op CommentOperation) encode(e *encoder) {
+	e.string(op.ParentAuthor)
+	e.string(op.ParentPermlink)
+	e.string(op.Author)
+	e.string(op.Permlink)
+	e.string(op.Title)
+	e.string(op.Body)
+	e.string(op.JSONMetadata)
+}
+
+// CommentOptionsOperation sets the payout options of a post. Beneficiaries can only
+// be set before the post gets votes and must be sorted by account.
+type CommentOptionsOperation struct {
+	Author               string
+	Permlink             string
+	MaxAcceptedPayout    Asset
+	PercentHbd           uint16
+	AllowVotes           bool
+	AllowCurationRewards bool
+	Beneficiaries        []Beneficiary
+}
+
+// commentOptionsJSON is the JSON form of CommentOptionsOperation, with beneficiaries in extensions.
+type commentOptionsJSON struct {
+	Author               string            `json:"author"`
+	Permlink             string            `json:"permlink"`
+	MaxAcceptedPayout    Asset             `json:"max_accepted_payout"`
+	PercentHbd           uint16            `json:"percent_hbd"`
+	AllowVotes           bool              `json:"allow_votes"`
+	AllowCurationRewards bool              `json:"allow_curation_rewards"`
+	Extensions           []json.RawMessage `json:"extensions"`
+}
+
+// beneficiariesExtension is the id of the beneficiaries comment_options extension.
+const beneficiariesExtension = 0
+
+// NewCommentOptions returns comment options for author/permlink with votes and curation allowed.
+// beneficiaries are sorted by account.
+func NewCommentOptions(author, permlink string, maxAcceptedPayout Asset, percentHbd int, beneficiaries []Beneficiary) (CommentOptionsOperation, error) {
+	if percentHbd < 0 || percentHbd > percent100 {
+		return CommentOptionsOperation{}, fmt.Errorf("percent hbd %d is not between 0 and %d", percentHbd, percent100)
+	}
+	sorted := append([]Beneficiary{}, beneficiaries...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Account < sorted[j].Account })
+	op := CommentOptionsOperation{
+		Author:               author,
+		Permlink:             permlink,
+		MaxAcceptedPayout:    maxAcceptedPayout,
+		PercentHbd:           uint16(percentHbd),
+		AllowVotes:           true,
+		AllowCurationRewards: true,
+		Beneficiaries:        sorted,
+	}
+	return op, op.Validate()
+}
+
+// Type returns CommentOptionsOp.
+func (op CommentOptionsOperation) Type() OpType { return CommentOptionsOp }
+
+// Validate checks the payout, percent and beneficiaries, which must be sorted,
+// unique and add up to at most 100%.
+func (op CommentOptionsOperation) Validate() error {
+	if err := ValidateAccountName(op.Author); err != nil {
+		return err
+	}
+	if err := ValidatePermlink(op.Permlink); err != nil {
+		return err
+	}
+	if err := validateAsset("max accepted payout", op.MaxAcceptedPayout, false, HBD); err != nil {
+		return err
+	}
+	if op.PercentHbd > percent100 {
+		return fmt.Errorf("percent hbd %d is more than %d", op.PercentHbd, percent100)
+	}
+	if len(op.Beneficiaries) > maxBeneficiaries {
+		return fmt.Errorf("comment options have more than %d beneficiaries", maxBeneficiaries)
+	}
+	total := 0
+	for i, b := range op.Beneficiaries {
+		if err := ValidateAccountName(b.Account); err != nil {
+			return err
+		}
+		if b.Weight < 1 || b.Weight > percent100 {
+			return fmt.Errorf("beneficiary %s weight %d is not between 1 and %d", b.Account, b.Weight, percent100)
+		}
+		if i > 0 && op.Beneficiaries[i-1].Account >= b.Account {
+			return fmt.Errorf("beneficiaries must be sorted by account and unique")
+		}
+		total += b.Weight
+	}
+	if total > percent100 {
+		return fmt.Errorf("beneficiary weights add up to more than %d", percent100)
+	}
+	return nil
+}
+
+// MarshalJSON encodes beneficiaries as a comment_options extension.
+func (op CommentOptionsOperation) MarshalJSON() ([]byte, error) {
+	out := commentOptionsJSON{
+		Author:               op.Author,
+		Permlink:             op.Permlink,
+		MaxAcceptedPayout:    op.MaxAcceptedPayout,
+		PercentHbd:           op.PercentHbd,
+		AllowVotes:           op.AllowVotes,
+		AllowCurationRewards: op.AllowCurationRewards,
+		Extensions:           []json.RawMessage{},
+	}
+	if len(op.Beneficiaries) > 0 {
+		b, err := json.Marshal([]interface{}{beneficiariesExtension, map[string]interface{}{"beneficiaries": op.Beneficiaries}})
+		if err != nil {
+			return nil, err
+		}
+		out.Extensions = append(out.Extensions, b)
+	}
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON decodes comment options, reading beneficiaries from the extensions.
+// Extensions may be in the condenser form, [0, {...}], or the appbase form,
+// {"type": "comment_payout_beneficiaries", "value": {...}}.
+func (op *CommentOptionsOperation) UnmarshalJSON(b []byte) error {
+	var in commentOptionsJSON
+	if err := json.Unmarshal(b, &in); err != nil {
+		return err
+	}
+	*op = CommentOptionsOperation{
+		Author:               in.Author,
+		Permlink:             in.Permlink,
+		MaxAcceptedPayout:    in.MaxAcceptedPayout,
+		PercentHbd:           in.PercentHbd,
+		AllowVotes:           in.AllowVotes,
+		AllowCurationRewards: in.AllowCurationRewards,
+	}
+	for _, raw := range in.Extensions {
+		ext, err := commentOptionsExtension(raw)
+		if err != nil {
+			return err
+		}
+		var value struct {
+			Beneficiaries []Beneficiary `json:"beneficiaries"`
+		}
+		if err := json.Unmarshal(ext, &value); err != nil {
+			return err
+		}
+		op.Beneficiaries = append(op.Beneficiaries, value.Beneficiaries...)
+	}
+	return nil
+}
+
+// commentOptionsExtension returns the value of a comment_options extension in
+// either the condenser or the appbase form.
+func commentOptionsExtension(raw json.RawMessage) (json.RawMessage, error) {
+	var pair []json.RawMessage
+	if err := json.Unmarshal(raw, &pair); err == nil && len(pair) == 2 {
+		return pair[1], nil
+	}
+	var variant struct {
+		Type  string          `json:"type"`
+		Value json.RawMessage `json:"value"`
+	}
+	if err := json.Unmarshal(raw, &variant); err == nil && variant.Type != "" && variant.Value != nil {
+		return variant.Value, nil
+	}
+	return nil, fmt.Errorf("invalid comment options extension %s", raw)
+}
+
+func (op CommentOptionsOperation) encode(e *encoder) {
+	e.string(op.Author)
+	e.string(op.Permlink)
+	e.asset(op.MaxAcceptedPayout)
+	e.uint16(op.PercentHbd)
+	e.bool(op.AllowVotes)
+	e.bool(op.AllowCurationRewards)
+	if len(op.Beneficiaries) == 0 {
+		e.varint(0)
+		return
+	}
+	e.varint(1)
+	e.varint(beneficiariesExtension)
+	e.varint(uint64(len(op.Beneficiaries)))
+	for _, b := range op.Beneficiaries {
+		e.string(b.Account)
+		e.uint16(uint16(b.Weight))
+	}
+}
+
+// DeleteCommentOperation deletes a post or comment without votes or replies.
+type DeleteCommentOperation struct {
+	Author   string `json:"author"`
+	Permlink string `json:"permlink"`
+}
+
+// NewDeleteComment returns the deletion of author/permlink.
+func NewDeleteComment(author, permlink string) (DeleteCommentOperation, error) {
+	op := DeleteCommentOperation{Author: author, Permlink: permlink}
+	return op, op.Validate()
+}
+
+// Type returns DeleteCommentOp.
+func (op DeleteCommentOperation) Type() OpType { return DeleteCommentOp }
+
+// Validate checks the author and permlink.
+func (op DeleteCommentOperation) Validate() error {
+	if err := ValidateAccountName(op.Author); err != nil {
+		return err
+	}
+	return ValidatePermlink(op.Permlink)
+}
+
+func (op DeleteCommentOperation) encode(e *encoder) {
+	e.string(op.Author)
+	e.string(op.Permlink)
+}
+
+// TransferOperation sends HIVE or HBD from one account to another.
+type TransferOperation struct {
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Amount Asset  `json:"amount"`
+	Memo   string `json:"memo"`
+}
+
+// NewTransfer returns a transfer of amount from from to to.
+func NewTransfer(from, to string, amount Asset, memo string) (TransferOperation, error) {
+	op := TransferOperation{From: from, To: to, Amount: amount, Memo: memo}
+	return op, op.Validate()
+}
+
+// Type returns TransferOp.
+func (op TransferOperation) Type() OpType { return TransferOp }
+
+// Validate checks the names, that the amount is positive HIVE or HBD, and the memo.
+func (op TransferOperation) Validate() error {
+	if err := validateAccounts(op.From, op.To); err != nil {
+		return err
+	}
+	if err := validateAsset("transfer amount", op.Amount, true, HIVE, HBD); err != nil {
+		return err
+	}
+	return validateMemo(op.Memo)
+}
+
+func (op TransferOperation) encode(e *encoder) {
+	e.string(op.From)
+	e.string(op.To)
+	e.asset(op.Amount)
+	e.string(op.Memo)
+}
+
+// TransferToVestingOperation powers up HIVE from From into Hive Power of To.
+type TransferToVestingOperation struct {
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Amount Asset  `json:"amount"`
+}
+
+// NewTransferToVesting returns a power up of amount from from to to.
+func NewTransferToVesting(from, to string, amount Asset) (TransferToVestingOperation, error) {
+	op := TransferToVestingOperation{From: from, To: to, Amount: amount}
+	return op, op.Validate()
+}
+
+// Type returns TransferToVestingOp.
+func (op TransferToVestingOperation) Type() OpType { return TransferToVestingOp }
+
+// Validate checks the names and that the amount is positive HIVE.
+func (op TransferToVestingOperation) Validate() error {
+	if err := validateAccounts(op.From, op.To); err != nil {
+		return err
+	}
+	return validateAsset("power up amount", op.Amount, true, HIVE)
+}
+
+func (op TransferToVestingOperation) encode(e *encoder) {
+	e.string(op.From)
+	e.string(op.To)
+	e.asset(op.Amount)
+}
+
+// WithdrawVestingOperation starts a power down of VestingShares, or stops it when zero.
+type WithdrawVestingOperation struct {
+	Account       string `json:"account"`
+	VestingShares Asset  `json:"vesting_shares"`
+}
+
+// NewWithdrawVesting returns a power down of vestingShares by account.
+func NewWithdrawVesting(account string, vestingShares Asset) (WithdrawVestingOperation, error) {
+	op := WithdrawVestingOperation{Account: account, VestingShares: vestingShares}
+	return op, op.Validate()
+}
+
+// Type returns WithdrawVestingOp.
+func (op WithdrawVestingOperation) Type() OpType { return WithdrawVestingOp }
+
+// Validate checks the name and that the shares are VESTS.
+func (op WithdrawVestingOperation) Validate() error {
+	if err := ValidateAccountName(op.Account); err != nil {
+		return err
+	}
+	return validateAsset("vesting shares", op.VestingShares, false, VESTS)
+}
+
+func (op WithdrawVestingOperation) encode(e *encoder) {
+	e.string(op.Account)
+	e.asset(op.VestingShares)
+}
+
+// SetWithdrawVestingRouteOperation routes Percent of the power down of FromAccount to ToAccount.
+type SetWithdrawVestingRouteOperation struct {
+	FromAccount string `json:"from_account"`
+	ToAccount   string `json:"to_account"`
+	Percent     uint16 `json:"percent"`
+	AutoVest    bool   `json:"auto_vest"`
+}
+
+// NewSetWithdrawVestingRoute returns a route sending percent of the power down of from to to.
+// A percent of 0 removes the route.
+func NewSetWithdrawVestingRoute(from, to string, percent int, autoVest bool) (SetWithdrawVestingRouteOperation, error) {
+	if percent < 0 || percent > percent100 {
+		return SetWithdrawVestingRouteOperation{}, fmt.Errorf("withdraw route percent %d is not between 0 and %d", percent, percent100)
+	}
+	op := SetWithdrawVestingRouteOperation{FromAccount: from, ToAccount: to, Percent: uint16(percent), AutoVest: autoVest}
+	return op, op.Validate()
+}
+
+// Type returns SetWithdrawVestingRouteOp.
+func (op SetWithdrawVestingRouteOperation) Type() OpType { return SetWithdrawVestingRouteOp }
+
+// Validate checks the names and the percent.
+func (op SetWithdrawVestingRouteOperation) Validate() error {
+	if err := validateAccounts(op.FromAccount, op.ToAccount); err != nil {
+		return err
+	}
+	if op.Percent > percent100 {
+		return fmt.Errorf("withdraw route percent %d is more than %d", op.Percent, percent100)
+	}
+	return nil
+}
+
+func (op SetWithdrawVestingRouteOperation) encode(e *encoder) {
+	e.string(op.FromAccount)
+	e.string(op.ToAccount)
+	e.uint16(op.Percent)
+	e.bool(op.AutoVest)
+}
+
+// DelegateVestingSharesOperation delegates VestingShares to Delegatee, replacing
+// any previous delegation. Zero removes the delegation.
+type DelegateVestingSharesOperation struct {
+	Delegator     string `json:"delegator"`
+	Delegatee     string `json:"delegatee"`
+	VestingShares Asset  `json:"vesting_shares"`
+}
+
+// NewDelegateVestingShares returns a delegation of vestingShares from delegator to delegatee.
+func NewDelegateVestingShares(delegator, delegatee string, vestingShares Asset) (DelegateVestingSharesOperation, error) {
+	op := DelegateVestingSharesOperation{Delegator: delegator, Delegatee: delegatee, VestingShares: vestingShares}
+	return op, op.Validate()
+}
+
+// Type returns DelegateVestingSharesOp.
+func (op DelegateVestingSharesOperation) Type() OpType { return DelegateVestingSharesOp }
+
+// Validate checks the names, that they differ and that the shares are VESTS.
+func (op DelegateVestingSharesOperation) Validate() error {
+	if err := validateAccounts(op.Delegator, op.Delegatee); err != nil {
+		return err
+	}
+	if op.Delegator == op.Delegatee {
+		return fmt.Errorf("cannot delegate to yourself")
+	}
+	return validateAsset("vesting shares", op.VestingShares, false, VESTS)
+}
+
+func (op DelegateVestingSharesOperation) encode(e *encoder) {
+	e.string(op.Delegator)
+	e.string(op.Delegatee)
+	e.asset(op.VestingShares)
+}
+
+// ClaimRewardBalanceOperation moves pending rewards into the balances of Account.
+type ClaimRewardBalanceOperation struct {
+	Account     string `json:"account"`
+	RewardHive  Asset  `json:"reward_hive"`
+	RewardHbd   Asset  `json:"reward_hbd"`
+	RewardVests Asset  `json:"reward_vests"`
+}
+
+// NewClaimRewardBalance returns a claim of the rewards of account.
+func NewClaimRewardBalance(account string, rewardHive, rewardHbd, rewardVests Asset) (ClaimRewardBalanceOperation, error) {
+	op := ClaimRewardBalanceOperation{Account: account, RewardHive: rewardHive, RewardHbd: rewardHbd, RewardVests: rewardVests}
+	return op, op.Validate()
+}
+
+// Type returns ClaimRewardBalanceOp.
+func (op ClaimRewardBalanceOperation) Type() OpType { return ClaimRewardBalanceOp }
+
+// Validate checks the name, the reward symbols and that something is claimed.
+func (op ClaimRewardBalanceOperation) Validate() error {
+	if err := ValidateAccountName(op.Account); err != nil {
+		return err
+	}
+	if err := validateAsset("reward hive", op.RewardHive, false, HIVE); err != nil {
+		return err
+	}
+	if err := validateAsset("reward hbd", op.RewardHbd, false, HBD); err != nil {
+		return err
+	}
+	if err := validateAsset("reward vests", op.RewardVests, false, VESTS); err != nil {
+		return err
+	}
+	if op.RewardHive.Amount == 0 && op.RewardHbd.Amount == 0 && op.RewardVests.Amount == 0 {
+		return fmt.Errorf("claim reward balance claims nothing")
+	}
+	return nil
+}
+
+func (op ClaimRewardBalanceOperation) encode(e *encoder) {
+	e.string(op.Account)
+	e.asset(op.RewardHive)
+	e.asset(op.RewardHbd)
+	e.asset(op.RewardVests)
+}
+
+// AccountUpdate2Operation updates the metadata of an account with its posting key.
+// Changing keys is not supported by this package.
+type AccountUpdate2Operation struct {
+	Account             string     `json:"account"`
+	JSONMetadata        string     `json:"json_metadata"`
+	PostingJSONMetadata string     `json:"posting_json_metadata"`
+	Extensions          Extensions `json:"extensions"`
+}
+
+// NewAccountUpdate2 returns an update of the posting metadata of account, such as its profile.
+func NewAccountUpdate2(account string, postingMetadata interface{}) (AccountUpdate2Operation, error) {
+	b, err := json.Marshal(postingMetadata)
+	if err != nil {
+		return AccountUpdate2Operation{}, err
+	}
+	op := AccountUpdate2Operation{Account: account, PostingJSONMetadata: string(b)}
+	return op, op.Validate()
+}
+
+// Type returns AccountUpdate2Op.
+func (op AccountUpdate2Operation) Type() OpType { return AccountUpdate2Op }
+
+// Validate checks the name and the metadata.
+func (op AccountUpdate2Operation) Validate() error {
+	if err := ValidateAccountName(op.Account); err != nil {
+		return err
+	}
+	if err := validateJSON("json_metadata", op.JSONMetadata); err != nil {
+		return err
+	}
+	return validateJSON("posting_json_metadata", op.PostingJSONMetadata)
+}
+
+func (op AccountUpdate2Operation) encode(e *encoder) {
+	e.string(op.Account)
+	// owner, active, posting and memo_key are optional and left unset.
+	e.uint8(0)
+	e.uint8(0)
+	e.uint8(0)
+	e.uint8(0)
+	e.string(op.JSONMetadata)
+	e.string(op.PostingJSONMetadata)
+	e.extensions(op.Extensions)
+}
+
+// AccountWitnessVoteOperation approves or unapproves Witness.
+type AccountWitnessVoteOperation struct {
+	Account string `json:"account"`
+	Witness string `json:"witness"`
+	Approve bool   `json:"approve"`
+}
+
+// NewAccountWitnessVote returns a witness vote by account.
+func NewAccountWitnessVote(account, witness string, approve bool) (AccountWitnessVoteOperation, error) {
+	op := AccountWitnessVoteOperation{Account: account, Witness: witness, Approve: approve}
+	return op, op.Validate()
+}
+
+// Type returns AccountWitnessVoteOp.
+func (op AccountWitnessVoteOperation) Type() OpType { return AccountWitnessVoteOp }
+
+// Validate checks the names.
+func (op AccountWitnessVoteOperation) Validate() error {
+	return validateAccounts(op.Account, op.Witness)
+}
+
+func (op AccountWitnessVoteOperation) encode(e *encoder) {
+	e.string(op.Account)
+	e.string(op.Witness)
+	e.bool(op.Approve)
+}
+
+// AccountWitnessProxyOperation lets Proxy vote for witnesses on behalf of Account.
+// An empty Proxy removes it.
+type AccountWitnessProxyOperation struct {
+	Account string `json:"account"`
+	Proxy   string `json:"proxy"`
+}
+
+// NewAccountWitnessProxy returns an operation setting the witness proxy of account.
+func NewAccountWitnessProxy(account, proxy string) (AccountWitnessProxyOperation, error) {
+	op := AccountWitnessProxyOperation{Account: account, Proxy: proxy}
+	return op, op.Validate()
+}
+
+// Type returns AccountWitnessProxyOp.
+func (op AccountWitnessProxyOperation) Type() OpType { return AccountWitnessProxyOp }
+
+// Validate checks the names and that the account is not its own proxy.
+func (op AccountWitnessProxyOperation) Validate() error {
+	if err := ValidateAccountName(op.Account); err != nil {
+		return err
+	}
+	if op.Proxy == "" {
+		return nil
+	}
+	if err := ValidateAccountName(op.Proxy); err != nil {
+		return err
+	}
+	if op.Proxy == op.Account {
+		return fmt.Errorf("cannot proxy to yourself")
+	}
+	return nil
+}
+
+func (op AccountWitnessProxyOperation) encode(e *encoder) {
+	e.string(op.Account)
+	e.string(op.Proxy)
+}
+
+// ConvertOperation converts HBD into HIVE at the median price in 3.5 days.
+type ConvertOperation struct {
+	Owner     string `json:"owner"`
+	RequestID uint32 `json:"requestid"`
+	Amount    Asset  `json:"amount"`
+}
+
+// NewConvert returns a conversion of amount by owner.
+func NewConvert(owner string, requestID uint32, amount Asset) (ConvertOperation, error) {
+	op := ConvertOperation{Owner: owner, RequestID: requestID, Amount: amount}
+	return op, op.Validate()
+}
+
+// Type returns ConvertOp.
+func (op ConvertOperation) Type() OpType { return ConvertOp }
+
+// Validate checks the name and that the amount is positive HBD.
+func (op ConvertOperation) Validate() error {
+	if err := ValidateAccountName(op.Owner); err != nil {
+		return err
+	}
+	return validateAsset("convert amount", op.Amount, true, HBD)
+}
+
+func (op ConvertOperation) encode(e *encoder) {
+	e.string(op.Owner)
+	e.uint32(op.RequestID)
+	e.asset(op.Amount)
+}
+
+// CollateralizedConvertOperation converts HIVE into HBD right away, keeping the
+// HIVE as collateral until the conversion settles.
+type CollateralizedConvertOperation struct {
+	Owner     string `json:"owner"`
+	RequestID uint32 `json:"requestid"`
+	Amount    Asset  `json:"amount"`
+}
+
+// NewCollateralizedConvert returns a collateralized conversion of amount by owner.
+func NewCollateralizedConvert(owner string, requestID uint32, amount Asset) (CollateralizedConvertOperation, error) {
+	op := CollateralizedConvertOperation{Owner: owner, RequestID: requestID, Amount: amount}
+	return op, op.Validate()
+}
+
+// Type returns CollateralizedConvertOp.
+func (op CollateralizedConvertOperation) Type() OpType { return CollateralizedConvertOp }
+
+// Validate checks the name and that the amount is positive HIVE.
+func (op CollateralizedConvertOperation) Validate() error {
+	if err := ValidateAccountName(op.Owner); err != nil {
+		return err
+	}
+	return validateAsset("collateral amount", op.Amount, true, HIVE)
+}
+
+func (op CollateralizedConvertOperation) encode(e *encoder) {
+	e.string(op.Owner)
+	e.uint32(op.RequestID)
+	e.asset(op.Amount)
+}
+
+// MaxLimitOrderExpiration is how far in the future a limit order may expire.
+const MaxLimitOrderExpiration = 28 * 24 * time.Hour
+
+// LimitOrderCreateOperation places an order on the internal market.
+type LimitOrderCreateOperation struct {
+	Owner        string `json:"owner"`
+	OrderID      uint32 `json:"orderid"`
+	AmountToSell Asset  `json:"amount_to_sell"`
+	MinToReceive Asset  `json:"min_to_receive"`
+	FillOrKill   bool   `json:"fill_or_kill"`
+	Expiration   Time   `json:"expiration"`
+}
+
+// NewLimitOrderCreate returns an order by owner selling amountToSell for at least minToReceive.
+// The order must expire in the future, within MaxLimitOrderExpiration.
+func NewLimitOrderCreate(owner string, orderID uint32, amountToSell, minToReceive Asset, fillOrKill bool, expiration Time) (LimitOrderCreateOperation, error) {
+	op := LimitOrderCreateOperation{Owner: owner, OrderID: orderID, AmountToSell: amountToSell, MinToReceive: minToReceive, FillOrKill: fillOrKill, Expiration: expiration}
+	if err := op.Validate(); err != nil {
+		return op, err
+	}
+	now := time.Now()
+	if !expiration.After(now) {
+		return op, fmt.Errorf("limit order expiration %s is not in the future", expiration)
+	}
+	if expiration.Sub(now) > MaxLimitOrderExpiration {
+		return op, fmt.Errorf("limit order expiration %s is more than %s away", expiration, MaxLimitOrderExpiration)
+	}
+	return op, nil
+}
+
+// Type returns LimitOrderCreateOp.
+func (op LimitOrderCreateOperation) Type() OpType { return LimitOrderCreateOp }
+
+// Validate checks the name, that the order trades positive HIVE against HBD, and
+// that it has an expiration. It does not compare the expiration with the current
+// time, so orders from history stay valid.
+func (op LimitOrderCreateOperation) Validate() error {
+	if err := ValidateAccountName(op.Owner); err != nil {
+		return err
+	}
+	if err := validateAsset("amount to sell", op.AmountToSell, true, HIVE, HBD); err != nil {
+		return err
+	}
+	if err := validateAsset("min to receive", op.MinToReceive, true, HIVE, HBD); err != nil {
+		return err
+	}
+	if op.AmountToSell.Symbol == op.MinToReceive.Symbol {
+		return fmt.Errorf("limit order must trade HIVE against HBD")
+	}
+	if op.Expiration.IsZero() {
+		return fmt.Errorf("limit order has no expiration")
+	}
+	return nil
+}
+
+func (op LimitOrderCreateOperation) encode(e *encoder) {
+	e.string(op.Owner)
+	e.uint32(op.OrderID)
+	e.asset(op.AmountToSell)
+	e.asset(op.MinToReceive)
+	e.bool(op.FillOrKill)
+	e.time(op.Expiration)
+}
+
+// LimitOrderCancelOperation cancels an order of Owner.
+type LimitOrderCancelOperation struct {
+	Owner   string `json:"owner"`
+	OrderID uint32 `json:"orderid"`
+}
+
+// NewLimitOrderCancel returns the cancellation of the order orderID of owner.
+func NewLimitOrderCancel(owner string, orderID uint32) (LimitOrderCancelOperation, error) {
+	op := LimitOrderCancelOperation{Owner: owner, OrderID: orderID}
+	return op, op.Validate()
+}
+
+// Type returns LimitOrderCancelOp.
+func (op LimitOrderCancelOperation) Type() OpType { return LimitOrderCancelOp }
+
+// Validate checks the name.
+func (op LimitOrderCancelOperation) Validate() error {
+	return ValidateAccountName(op.Owner)
+}
+
+func (op LimitOrderCancelOperation) encode(e *encoder) {
+	e.string(op.Owner)
+	e.uint32(op.OrderID)
+}
+
+// TransferToSavingsOperation moves HIVE or HBD into the savings of To.
+type TransferToSavingsOperation struct {
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Amount Asset  `json:"amount"`
+	Memo   string `json:"memo"`
+}
+
+// NewTransferToSavings returns a transfer of amount from from into the savings of to.
+func NewTransferToSavings(from, to string, amount Asset, memo string) (TransferToSavingsOperation, error) {
+	op := TransferToSavingsOperation{From: from, To: to, Amount: amount, Memo: memo}
+	return op, op.Validate()
+}
+
+// Type returns TransferToSavingsOp.
+func (op TransferToSavingsOperation) Type() OpType { return TransferToSavingsOp }
+
+// Validate checks the names, that the amount is positive HIVE or HBD, and the memo.
+func (op TransferToSavingsOperation) Validate() error {
+	if err := validateAccounts(op.From, op.To); err != nil {
+		return err
+	}
+	if err := validateAsset("savings amount", op.Amount, true, HIVE, HBD); err != nil {
+		return err
+	}
+	return validateMemo(op.Memo)
+}
+
+func (op TransferToSavingsOperation) encode(e *encoder) {
+	e.string(op.From)
+	e.string(op.To)
+	e.asset(op.Amount)
+	e.string(op.Memo)
+}
+
+// TransferFromSavingsOperation withdraws from savings to To after 3 days.
+type TransferFromSavingsOperation struct {
+	From      string `json:"from"`
+	RequestID uint32 `json:"request_id"`
+	To        string `json:"to"`
+	Amount    Asset  `json:"amount"`
+	Memo      string `json:"memo"`
+}
+
+// NewTransferFromSavings returns a withdrawal of amount from the savings of from to to.
+// requestID must be unique among the pending withdrawals of from.
+func NewTransferFromSavings(from string, requestID uint32, to string, amount Asset, memo string) (TransferFromSavingsOperation, error) {
+	op := TransferFromSavingsOperation{From: from, RequestID: requestID, To: to, Amount: amount, Memo: memo}
+	return op, op.Validate()
+}
+
+// Type returns TransferFromSavingsOp.
+func (op TransferFromSavingsOperation) Type() OpType { return TransferFromSavingsOp }
+
+// Validate checks the names, that the amount is positive HIVE or HBD, and the memo.
+func (op TransferFromSavingsOperation) Validate() error {
+	if err := validateAccounts(op.From, op.To); err != nil {
+		return err
+	}
+	if err := validateAsset("savings amount", op.Amount, true, HIVE, HBD); err != nil {
+		return err
+	}
+	return validateMemo(op.Memo)
+}
+
+func (op TransferFromSavingsOperation) encode(e *encoder) {
+	e.string(op.From)
+	e.uint32(op.RequestID)
+	e.string(op.To)
+	e.asset(op.Amount)
+	e.string(op.Memo)
+}
+
+// CancelTransferFromSavingsOperation cancels a pending withdrawal from savings.
+type CancelTransferFromSavingsOperation struct {
+	From      string `json:"from"`
+	RequestID uint32 `json:"request_id"`
+}
+
+// NewCancelTransferFromSavings returns the cancellation of the savings withdrawal requestID of from.
+func NewCancelTransferFromSavings(from string, requestID uint32) (CancelTransferFromSavingsOperation, error) {
+	op := CancelTransferFromSavingsOperation{From: from, RequestID: requestID}
+	return op, op.Validate()
+}
+
+// Type returns CancelTransferFromSavingsOp.
+func (op CancelTransferFromSavingsOperation) Type() OpType { return CancelTransferFromSavingsOp }
+
+// Validate checks the name.
+func (op CancelTransferFromSavingsOperation) Validate() error {
+	return ValidateAccountName(op.From)
+}
+
+func (op CancelTransferFromSavingsOperation) encode(e *encoder) {
+	e.string(op.From)
+	e.uint32(op.RequestID)
+}
+
+// RecurrentTransferOperation sends Amount every Recurrence hours, Executions times.
+// A zero Amount cancels the recurrent transfer.
+type RecurrentTransferOperation struct {
+	From       string     `json:"from"`
+	To         string     `json:"to"`
+	Amount     Asset      `json:"amount"`
+	Memo       string     `json:"memo"`
+	Recurrence uint16     `json:"recurrence"`
+	Executions uint16     `json:"executions"`
+	Extensions Extensions `json:"extensions"`
+}
+
+// NewRecurrentTransfer returns a recurrent transfer of amount from from to to.
+func NewRecurrentTransfer(from, to string, amount Asset, memo string, recurrenceHours, executions uint16) (RecurrentTransferOperation, error) {
+	op := RecurrentTransferOperation{From: from, To: to, Amount: amount, Memo: memo, Recurrence: recurrenceHours, Executions: executions}
+	return op, op.Validate()
+}
+
+// Type returns RecurrentTransferOp.
+func (op RecurrentTransferOperation) Type() OpType { return RecurrentTransferOp }
+
+// Validate checks the names, amount, memo and schedule.
+func (op RecurrentTransferOperation) Validate() error {
+	if err := validateAccounts(op.From, op.To); err != nil {
+		return err
+	}
+	if op.From == op.To {
+		return fmt.Errorf("cannot set up a recurrent transfer to yourself")
+	}
+	if err := validateAsset("recurrent transfer amount", op.Amount, false, HIVE, HBD); err != nil {
+		return err
+	}
+	if err := validateMemo(op.Memo); err != nil {
+		return err
+	}
+	if op.Recurrence < minRecurrenceHours {
+		return fmt.Errorf("recurrence must be at least %d hours", minRecurrenceHours)
+	}
+	if op.Executions < minRecurrentPayments {
+		return fmt.Errorf("recurrent transfer needs at least %d executions", minRecurrentPayments)
+	}
+	return nil
+}
+
+func (op RecurrentTransferOperation) encode(e *encoder) {
+	e.string(op.From)
+	e.string(op.To)
+	e.asset(op.Amount)
+	e.string(op.Memo)
+	e.uint16(op.Recurrence)
+	e.uint16(op.Executions)
+	e.extensions(op.Extensions)
+}
+
+// UpdateProposalVotesOperation approves or unapproves DHF proposals.
+type UpdateProposalVotesOperation struct {
+	Voter       string     `json:"voter"`
+	ProposalIDs []int64    `json:"proposal_ids"`
+	Approve     bool       `json:"approve"`
+	Extensions  Extensions `json:"extensions"`
+}
+
+// NewUpdateProposalVotes returns an update of the votes of voter on proposalIDs.
+func NewUpdateProposalVotes(voter string, proposalIDs []int64, approve bool) (UpdateProposalVotesOperation, error) {
+	op := UpdateProposalVotesOperation{Voter: voter, ProposalIDs: proposalIDs, Approve: approve}
+	return op, op.Validate()
+}
+
+// Type returns UpdateProposalVotesOp.
+func (op UpdateProposalVotesOperation) Type() OpType { return UpdateProposalVotesOp }
+
+// Validate checks the name and that there are 1 to 5 unique proposal ids.
+func (op UpdateProposalVotesOperation) Validate() error {
+	if err := ValidateAccountName(op.Voter); err != nil {
+		return err
+	}
+	return validateProposalIDs("proposal votes", op.ProposalIDs)
+}
+
+func (op UpdateProposalVotesOperation) encode(e *encoder) {
+	e.string(op.Voter)
+	e.int64Set(op.ProposalIDs)
+	e.bool(op.Approve)
+	e.extensions(op.Extensions)
+}
+
+// FeedPublishOperation publishes the HBD/HIVE price feed of a witness.
+type FeedPublishOperation struct {
+	Publisher    string `json:"publisher"`
+	ExchangeRate Price  `json:"exchange_rate"`
+}
+
+// NewFeedPublish returns the feed exchangeRate published by the witness publisher.
+func NewFeedPublish(publisher string, exchangeRate Price) (FeedPublishOperation, error) {
+	op := FeedPublishOperation{Publisher: publisher, ExchangeRate: exchangeRate}
+	return op, op.Validate()
+}
+
+// Type returns FeedPublishOp.
+func (op FeedPublishOperation) Type() OpType { return FeedPublishOp }
+
+// Validate checks the name and that the price is a positive HIVE/HBD pair.
+func (op FeedPublishOperation) Validate() error {
+	if err := ValidateAccountName(op.Publisher); err != nil {
+		return err
+	}
+	return validatePrice("exchange rate", op.ExchangeRate)
+}
+
+func (op FeedPublishOperation) encode(e *encoder) {
+	e.string(op.Publisher)
+	e.asset(op.ExchangeRate.Base)
+	e.asset(op.ExchangeRate.Quote)
+}
+
+// ClaimAccountOperation claims an account creation ticket for Fee, or for
+// resource credits when Fee is zero.
+type ClaimAccountOperation struct {
+	Creator    string     `json:"creator"`
+	Fee        Asset      `json:"fee"`
+	Extensions Extensions `json:"extensions"`
+}
+
+// NewClaimAccount returns a claim of an account creation ticket by creator.
+func NewClaimAccount(creator string, fee Asset) (ClaimAccountOperation, error) {
+	op := ClaimAccountOperation{Creator: creator, Fee: fee}
+	return op, op.Validate()
+}
+
+// Type returns ClaimAccountOp.
+func (op ClaimAccountOperation) Type() OpType { return ClaimAccountOp }
+
+// Validate checks the name and that the fee is HIVE.
+func (op ClaimAccountOperation) Validate() error {
+	if err := ValidateAccountName(op.Creator); err != nil {
+		return err
+	}
+	return validateAsset("claim account fee", op.Fee, false, HIVE)
+}
+
+func (op ClaimAccountOperation) encode(e *encoder) {
+	e.string(op.Creator)
+	e.asset(op.Fee)
+	e.extensions(op.Extensions)
+}
+
+// ChangeRecoveryAccountOperation changes the account allowed to recover AccountToRecover.
+// The change takes effect after 30 days.
+type ChangeRecoveryAccountOperation struct {
+	AccountToRecover   string     `json:"account_to_recover"`
+	NewRecoveryAccount string     `json:"new_recovery_account"`
+	Extensions         Extensions `json:"extensions"`
+}
+
+// NewChangeRecoveryAccount returns a change of the recovery account of account to recoveryAccount.
+func NewChangeRecoveryAccount(account, recoveryAccount string) (ChangeRecoveryAccountOperation, error) {
+	op := ChangeRecoveryAccountOperation{AccountToRecover: account, NewRecoveryAccount: recoveryAccount}
+	return op, op.Validate()
+}
+
+// Type returns ChangeRecoveryAccountOp.
+func (op ChangeRecoveryAccountOperation) Type() OpType { return ChangeRecoveryAccountOp }
+
+// Validate checks the names.
+func (op ChangeRecoveryAccountOperation) Validate() error {
+	return validateAccounts(op.AccountToRecover, op.NewRecoveryAccount)
+}
+
+func (op ChangeRecoveryAccountOperation) encode(e *encoder) {
+	e.string(op.AccountToRecover)
+	e.string(op.NewRecoveryAccount)
+	e.extensions(op.Extensions)
+}
+
+// DeclineVotingRightsOperation gives up the voting rights of Account for good,
+// or cancels a pending request when Decline is false.
+type DeclineVotingRightsOperation struct {
+	Account string `json:"account"`
+	Decline bool   `json:"decline"`
+}
+
+// NewDeclineVotingRights returns a request of account to decline its voting rights.
+func NewDeclineVotingRights(account string, decline bool) (DeclineVotingRightsOperation, error) {
+	op := DeclineVotingRightsOperation{Account: account, Decline: decline}
+	return op, op.Validate()
+}
+
+// Type returns DeclineVotingRightsOp.
+func (op DeclineVotingRightsOperation) Type() OpType { return DeclineVotingRightsOp }
+
+// Validate checks the name.
+func (op DeclineVotingRightsOperation) Validate() error {
+	return ValidateAccountName(op.Account)
+}
+
+func (op DeclineVotingRightsOperation) encode(e *encoder) {
+	e.string(op.Account)
+	e.bool(op.Decline)
+}
+
+// CustomOperation carries binary application data. Data is hex encoded.
+type CustomOperation struct {
+	RequiredAuths []string `json:"required_auths"`
+	ID            uint16   `json:"id"`
+	Data          string   `json:"data"`
+}
+
+// NewCustom returns a custom operation with id and data, signed with the active keys of requiredAuths.
+func NewCustom(requiredAuths []string, id uint16, data []byte) (CustomOperation, error) {
+	op := CustomOperation{RequiredAuths: requiredAuths, ID: id, Data: hex.EncodeToString(data)}
+	return op, op.Validate()
+}
+
+// Type returns CustomOp.
+func (op CustomOperation) Type() OpType { return CustomOp }
+
+// Validate checks the auths and that Data is hex encoded.
+func (op CustomOperation) Validate() error {
+	if len(op.RequiredAuths) == 0 {
+		return fmt.Errorf("custom needs at least one required auth")
+	}
+	if err := validateAccounts(op.RequiredAuths...); err != nil {
+		return err
+	}
+	if _, err := hex.DecodeString(op.Data); err != nil {
+		return fmt.Errorf("custom data is not hex encoded: %v", err)
+	}
+	return nil
+}
+
+func (op CustomOperation) encode(e *encoder) {
+	data, _ := hex.DecodeString(op.Data)
+	e.stringSet(op.RequiredAuths)
+	e.uint16(op.ID)
+	e.string(string(data))
+}
+
+// LimitOrderCreate2Operation places an order on the internal market at ExchangeRate,
+// which is the price of AmountToSell.
+type LimitOrderCreate2Operation struct {
+	Owner        string `json:"owner"`
+	OrderID      uint32 `json:"orderid"`
+	AmountToSell Asset  `json:"amount_to_sell"`
+	FillOrKill   bool   `json:"fill_or_kill"`
+	ExchangeRate Price  `json:"exchange_rate"`
+	Expiration   Time   `json:"expiration"`
+}
+
+// NewLimitOrderCreate2 returns an order by owner selling amountToSell at exchangeRate.
+// The order must expire in the future, within MaxLimitOrderExpiration.
+func NewLimitOrderCreate2(owner string, orderID uint32, amountToSell Asset, exchangeRate Price, fillOrKill bool, expiration Time) (LimitOrderCreate2Operation, error) {
+	op := LimitOrderCreate2Operation{Owner: owner, OrderID: orderID, AmountToSell: amountToSell, FillOrKill: fillOrKill, ExchangeRate: exchangeRate, Expiration: expiration}
+	if err := op.Validate(); err != nil {
+		return op, err
+	}
+	now := time.Now()
+	if !expiration.After(now) {
+		return op, fmt.Errorf("limit order expiration %s is not in the future", expiration)
+	}
+	if expiration.Sub(now) > MaxLimitOrderExpiration {
+		return op, fmt.Errorf("limit order expiration %s is more than %s away", expiration, MaxLimitOrderExpiration)
+	}
+	return op, nil
+}
+
+// Type returns LimitOrderCreate2Op.
+func (op LimitOrderCreate2Operation) Type() OpType { return LimitOrderCreate2Op }
+
+// Validate checks the name, that the order sells the base of a HIVE/HBD price
+// for a non zero amount, and that it has an expiration.
+func (op LimitOrderCreate2Operation) Validate() error {
+	if err := ValidateAccountName(op.Owner); err != nil {
+		return err
+	}
+	if err := validateAsset("amount to sell", op.AmountToSell, true, HIVE, HBD); err != nil {
+		return err
+	}
+	if err := validatePrice("exchange rate", op.ExchangeRate); err != nil {
+		return err
+	}
+	if op.AmountToSell.Symbol != op.ExchangeRate.Base.Symbol {
+		return fmt.Errorf("amount to sell must be in the base of the exchange rate")
+	}
+	if receive, err := op.ExchangeRate.Convert(op.AmountToSell); err != nil || receive.Amount == 0 {
+		return fmt.Errorf("amount to sell %s is worth nothing at the exchange rate", op.AmountToSell)
+	}
+	if op.Expiration.IsZero() {
+		return fmt.Errorf("limit order has no expiration")
+	}
+	return nil
+}
+
+func (op LimitOrderCreate2Operation) encode(e *encoder) {
+	e.string(op.Owner)
+	e.uint32(op.OrderID)
+	e.asset(op.AmountToSell)
+	e.bool(op.FillOrKill)
+	e.asset(op.ExchangeRate.Base)
+	e.asset(op.ExchangeRate.Quote)
+	e.time(op.Expiration)
+}
+
+// EscrowTransferOperation locks HbdAmount and HiveAmount from From in escrow for To,
+// with Agent settling disputes for Fee.
+type EscrowTransferOperation struct {
+	From                 string `json:"from"`
+	To                   string `json:"to"`
+	HbdAmount            Asset  `json:"hbd_amount"`
+	HiveAmount           Asset  `json:"hive_amount"`
+	EscrowID             uint32 `json:"escrow_id"`
+	Agent                string `json:"agent"`
+	Fee                  Asset  `json:"fee"`
+	JSONMeta             string `json:"json_meta"`
+	RatificationDeadline Time   `json:"ratification_deadline"`
+	EscrowExpiration     Time   `json:"escrow_expiration"`
+}
+
+// NewEscrowTransfer returns an escrow of hbdAmount and hiveAmount from from to to.
+// metadata is encoded to JSON and may be nil.
+func NewEscrowTransfer(from, to, agent string, escrowID uint32, hbdAmount, hiveAmount, fee Asset, ratificationDeadline, escrowExpiration Time, metadata interface{}) (EscrowTransferOperation, error) {
+	op := EscrowTransferOperation{From: from, To: to, HbdAmount: hbdAmount, HiveAmount: hiveAmount, EscrowID: escrowID, Agent: agent, Fee: fee, RatificationDeadline: ratificationDeadline, EscrowExpiration: escrowExpiration}
+	if metadata != nil {
+		b, err := json.Marshal(metadata)
+		if err != nil {
+			return EscrowTransferOperation{}, err
+		}
+		op.JSONMeta = string(b)
+	}
+	return op, op.Validate()
+}
+
+// Type returns EscrowTransferOp.
+func (op EscrowTransferOperation) Type() OpType { return EscrowTransferOp }
+
+// Validate checks the names, that the agent is a third party, the amounts and fee,
+// that the ratification deadline comes before the expiration and the metadata.
+func (op EscrowTransferOperation) Validate() error {
+	if err := validateAccounts(op.From, op.To, op.Agent); err != nil {
+		return err
+	}
+	if op.Agent == op.From || op.Agent == op.To {
+		return fmt.Errorf("escrow agent must not be the sender or the receiver")
+	}
+	if err := validateEscrowAmounts(op.HbdAmount, op.HiveAmount); err != nil {
+		return err
+	}
+	if err := validateAsset("escrow fee", op.Fee, false, HIVE, HBD); err != nil {
+		return err
+	}
+	if !op.RatificationDeadline.Before(op.EscrowExpiration.Time) {
+		return fmt.Errorf("escrow ratification deadline must be before the expiration")
+	}
+	return validateJSON("escrow json_meta", op.JSONMeta)
+}
+
+func (op EscrowTransferOperation) encode(e *encoder) {
+	e.string(op.From)
+	e.string(op.To)
+	e.asset(op.HbdAmount)
+	e.asset(op.HiveAmount)
+	e.uint32(op.EscrowID)
+	e.string(op.Agent)
+	e.asset(op.Fee)
+	e.string(op.JSONMeta)
+	e.time(op.RatificationDeadline)
+	e.time(op.EscrowExpiration)
+}
+
+// EscrowApproveOperation approves or rejects an escrow. Who is the receiver or the agent.
+type EscrowApproveOperation struct {
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Agent    string `json:"agent"`
+	Who      string `json:"who"`
+	EscrowID uint32 `json:"escrow_id"`
+	Approve  bool   `json:"approve"`
+}
+
+// NewEscrowApprove returns the approval by who of the escrow escrowID of from.
+func NewEscrowApprove(from, to, agent, who string, escrowID uint32, approve bool) (EscrowApproveOperation, error) {
+	op := EscrowApproveOperation{From: from, To: to, Agent: agent, Who: who, EscrowID: escrowID, Approve: approve}
+	return op, op.Validate()
+}
+
+// Type returns EscrowApproveOp.
+func (op EscrowApproveOperation) Type() OpType { return EscrowApproveOp }
+
+// Validate checks the names and that Who is the receiver or the agent.
+func (op EscrowApproveOperation) Validate() error {
+	if err := validateAccounts(op.From, op.To, op.Agent, op.Who); err != nil {
+		return err
+	}
+	if op.Who != op.To && op.Who != op.Agent {
+		return fmt.Errorf("escrow can only be approved by the receiver or the agent")
+	}
+	return nil
+}
+
+func (op EscrowApproveOperation) encode(e *encoder) {
+	e.string(op.From)
+	e.string(op.To)
+	e.string(op.Agent)
+	e.string(op.Who)
+	e.uint32(op.EscrowID)
+	e.bool(op.Approve)
+}
+
+// EscrowDisputeOperation hands an escrow over to its agent. Who is the sender or the receiver.
+type EscrowDisputeOperation struct {
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Agent    string `json:"agent"`
+	Who      string `json:"who"`
+	EscrowID uint32 `json:"escrow_id"`
+}
+
+// NewEscrowDispute returns a dispute by who of the escrow escrowID of from.
+func NewEscrowDispute(from, to, agent, who string, escrowID uint32) (EscrowDisputeOperation, error) {
+	op := EscrowDisputeOperation{From: from, To: to, Agent: agent, Who: who, EscrowID: escrowID}
+	return op, op.Validate()
+}
+
+// Type returns EscrowDisputeOp.
+func (op EscrowDisputeOperation) Type() OpType { return EscrowDisputeOp }
+
+// Validate checks the names and that Who is the sender or the receiver.
+func (op EscrowDisputeOperation) Validate() error {
+	if err := validateAccounts(op.From, op.To, op.Agent, op.Who); err != nil {
+		return err
+	}
+	if op.Who != op.From && op.Who != op.To {
+		return fmt.Errorf("escrow can only be disputed by the sender or the receiver")
+	}
+	return nil
+}
+
+func (op EscrowDisputeOperation) encode(e *encoder) {
+	e.string(op.From)
+	e.string(op.To)
+	e.string(op.Agent)
+	e.string(op.Who)
+	e.uint32(op.EscrowID)
+}
+
+// EscrowReleaseOperation releases escrowed funds to Receiver, the sender or the receiver.
+type EscrowReleaseOperation struct {
+	From       string `json:"from"`
+	To         string `json:"to"`
+	Agent      string `json:"agent"`
+	Who        string `json:"who"`
+	Receiver   string `json:"receiver"`
+	EscrowID   uint32 `json:"escrow_id"`
+	HbdAmount  Asset  `json:"hbd_amount"`
+	HiveAmount Asset  `json:"hive_amount"`
+}
+
+// NewEscrowRelease returns a release by who of hbdAmount and hiveAmount of the escrow escrowID to receiver.
+func NewEscrowRelease(from, to, agent, who, receiver string, escrowID uint32, hbdAmount, hiveAmount Asset) (EscrowReleaseOperation, error) {
+	op := EscrowReleaseOperation{From: from, To: to, Agent: agent, Who: who, Receiver: receiver, EscrowID: escrowID, HbdAmount: hbdAmount, HiveAmount: hiveAmount}
+	return op, op.Validate()
+}
+
+// Type returns EscrowReleaseOp.
+func (op EscrowReleaseOperation) Type() OpType { return EscrowReleaseOp }
+
+// Validate checks the names, that Who is a party of the escrow, that Receiver is
+// the sender or the receiver, and the amounts.
+func (op EscrowReleaseOperation) Validate() error {
+	if err := validateAccounts(op.From, op.To, op.Agent, op.Who, op.Receiver); err != nil {
+		return err
+	}
+	if op.Who != op.From && op.Who != op.To && op.Who != op.Agent {
+		return fmt.Errorf("escrow can only be released by the sender, the receiver or the agent")
+	}
+	if op.Receiver != op.From && op.Receiver != op.To {
+		return fmt.Errorf("escrow can only be released to the sender or the receiver")
+	}
+	return validateEscrowAmounts(op.HbdAmount, op.HiveAmount)
+}
+
+func (op EscrowReleaseOperation) encode(e *encoder) {
+	e.string(op.From)
+	e.string(op.To)
+	e.string(op.Agent)
+	e.string(op.Who)
+	e.string(op.Receiver)
+	e.uint32(op.EscrowID)
+	e.asset(op.HbdAmount)
+	e.asset(op.HiveAmount)
+}
+
+// validateEscrowAmounts checks the HBD and HIVE amounts of an escrow are not negative and not both zero.
+func validateEscrowAmounts(hbd, hive Asset) error {
+	if err := validateAsset("escrow hbd amount", hbd, false, HBD); err != nil {
+		return err
+	}
+	if err := validateAsset("escrow hive amount", hive, false, HIVE); err != nil {
+		return err
+	}
+	if hbd.Amount == 0 && hive.Amount == 0 {
+		return fmt.Errorf("escrow needs a HBD or HIVE amount")
+	}
+	return nil
+}
+
+// CreateProposalOperation asks the Decentralized Hive Fund to pay DailyPay to
+// Receiver between StartDate and EndDate. Permlink is a post of Creator.
+type CreateProposalOperation struct {
+	Creator    string     `json:"creator"`
+	Receiver   string     `json:"receiver"`
+	StartDate  Time       `json:"start_date"`
+	EndDate    Time       `json:"end_date"`
+	DailyPay   Asset      `json:"daily_pay"`
+	Subject    string     `json:"subject"`
+	Permlink   string     `json:"permlink"`
+	Extensions Extensions `json:"extensions"`
+}
+
+// NewCreateProposal returns a proposal by creator paying dailyPay to receiver from start to end.
+func NewCreateProposal(creator, receiver string, start, end Time, dailyPay Asset, subject, permlink string) (CreateProposalOperation, error) {
+	op := CreateProposalOperation{Creator: creator, Receiver: receiver, StartDate: start, EndDate: end, DailyPay: dailyPay, Subject: subject, Permlink: permlink}
+	return op, op.Validate()
+}
+
+// Type returns CreateProposalOp.
+func (op CreateProposalOperation) Type() OpType { return CreateProposalOp }
+
+// Validate checks the names, that the proposal ends after it starts, the daily pay,
+// the subject and the permlink.
+func (op CreateProposalOperation) Validate() error {
+	if err := validateAccounts(op.Creator, op.Receiver); err != nil {
+		return err
+	}
+	if !op.EndDate.After(op.StartDate.Time) {
+		return fmt.Errorf("proposal must end after it starts")
+	}
+	return validateProposal(op.DailyPay, op.Subject, op.Permlink)
+}
+
+func (op CreateProposalOperation) encode(e *encoder) {
+	e.string(op.Creator)
+	e.string(op.Receiver)
+	e.time(op.StartDate)
+	e.time(op.EndDate)
+	e.asset(op.DailyPay)
+	e.string(op.Subject)
+	e.string(op.Permlink)
+	e.extensions(op.Extensions)
+}
+
+// UpdateProposalOperation lowers the daily pay, or changes the subject or permlink, of a proposal.
+type UpdateProposalOperation struct {
+	ProposalID int64      `json:"proposal_id"`
+	Creator    string     `json:"creator"`
+	DailyPay   Asset      `json:"daily_pay"`
+	Subject    string     `json:"subject"`
+	Permlink   string     `json:"permlink"`
+	Extensions Extensions `json:"extensions"`
+}
+
+// NewUpdateProposal returns an update by creator of the proposal proposalID.
+func NewUpdateProposal(proposalID int64, creator string, dailyPay Asset, subject, permlink string) (UpdateProposalOperation, error) {
+	op := UpdateProposalOperation{ProposalID: proposalID, Creator: creator, DailyPay: dailyPay, Subject: subject, Permlink: permlink}
+	return op, op.Validate()
+}
+
+// Type returns UpdateProposalOp.
+func (op UpdateProposalOperation) Type() OpType { return UpdateProposalOp }
+
+// Validate checks the id, the name, the daily pay, the subject and the permlink.
+func (op UpdateProposalOperation) Validate() error {
+	if op.ProposalID < 0 {
+		return fmt.Errorf("proposal id must not be negative")
+	}
+	if err := ValidateAccountName(op.Creator); err != nil {
+		return err
+	}
+	return validateProposal(op.DailyPay, op.Subject, op.Permlink)
+}
+
+func (op UpdateProposalOperation) encode(e *encoder) {
+	e.int64(op.ProposalID)
+	e.string(op.Creator)
+	e.asset(op.DailyPay)
+	e.string(op.Subject)
+	e.string(op.Permlink)
+	e.extensions(op.Extensions)
+}
+
+// validateProposal checks the daily pay is HBD and the subject and permlink of a proposal.
+func validateProposal(dailyPay Asset, subject, permlink string) error {
+	if err := validateAsset("proposal daily pay", dailyPay, false, HBD); err != nil {
+		return err
+	}
+	if subject == "" || len(subject) > maxProposalSubject {
+		return fmt.Errorf("proposal subject must be between 1 and %d bytes", maxProposalSubject)
+	}
+	if !utf8.ValidString(subject) {
+		return fmt.Errorf("proposal subject is not valid UTF-8")
+	}
+	return ValidatePermlink(permlink)
+}
+
+// RemoveProposalOperation removes proposals of ProposalOwner.
+type RemoveProposalOperation struct {
+	ProposalOwner string     `json:"proposal_owner"`
+	ProposalIDs   []int64    `json:"proposal_ids"`
+	Extensions    Extensions `json:"extensions"`
+}
+
+// NewRemoveProposal returns the removal of the proposals proposalIDs of owner.
+func NewRemoveProposal(owner string, proposalIDs []int64) (RemoveProposalOperation, error) {
+	op := RemoveProposalOperation{ProposalOwner: owner, ProposalIDs: proposalIDs}
+	return op, op.Validate()
+}
+
+// Type returns RemoveProposalOp.
+func (op RemoveProposalOperation) Type() OpType { return RemoveProposalOp }
+
+// Validate checks the name and that there are 1 to 5 unique proposal ids.
+func (op RemoveProposalOperation) Validate() error {
+	if err := ValidateAccountName(op.ProposalOwner); err != nil {
+		return err
+	}
+	return validateProposalIDs("proposal removal", op.ProposalIDs)
+}
+
+func (op RemoveProposalOperation) encode(e *encoder) {
+	e.string(op.ProposalOwner)
+	e.int64Set(op.ProposalIDs)
+	e.extensions(op.Extensions)
+}
@@ -0,0 +1,273 @@
+package gohive
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// OpType is the name of an operation, such as "vote".
+type OpType string
+
+// Operation names, in the order of their ids in the binary encoding.
+const (
+	VoteOp                      OpType = "vote"
+	CommentOp                   OpType = "comment"
+	TransferOp                  OpType = "transfer"
+	TransferToVestingOp         OpType = "transfer_to_vesting"
+	WithdrawVestingOp           OpType = "withdraw_vesting"
+	LimitOrderCreateOp          OpType = "limit_order_create"
+	LimitOrderCancelOp          OpType = "limit_order_cancel"
+	FeedPublishOp               OpType = "feed_publish"
+	ConvertOp                   OpType = "convert"
+	AccountCreateOp             OpType = "account_create"
+	AccountUpdateOp             OpType = "account_update"
+	WitnessUpdateOp             OpType = "witness_update"
+	AccountWitnessVoteOp        OpType = "account_witness_vote"
+	AccountWitnessProxyOp       OpType = "account_witness_proxy"
+	PowOp                       OpType = "pow"
+	CustomOp                    OpType = "custom"
+	ReportOverProductionOp      OpType = "report_over_production"
+	DeleteCommentOp             OpType = "delete_comment"
+	CustomJSONOp                OpType = "custom_json"
+	CommentOptionsOp            OpType = "comment_options"
+	SetWithdrawVestingRouteOp   OpType = "set_withdraw_vesting_route"
+	LimitOrderCreate2Op         OpType = "limit_order_create2"
+	ClaimAccountOp              OpType = "claim_account"
+	CreateClaimedAccountOp      OpType = "create_claimed_account"
+	RequestAccountRecoveryOp    OpType = "request_account_recovery"
+	RecoverAccountOp            OpType = "recover_account"
+	ChangeRecoveryAccountOp     OpType = "change_recovery_account"
+	EscrowTransferOp            OpType = "escrow_transfer"
+	EscrowDisputeOp             OpType = "escrow_dispute"
+	EscrowReleaseOp             OpType = "escrow_release"
+	Pow2Op                      OpType = "pow2"
+	EscrowApproveOp             OpType = "escrow_approve"
+	TransferToSavingsOp         OpType = "transfer_to_savings"
+	TransferFromSavingsOp       OpType = "transfer_from_savings"
+	CancelTransferFromSavingsOp OpType = "cancel_transfer_from_savings"
+	CustomBinaryOp              OpType = "custom_binary"
+	DeclineVotingRightsOp       OpType = "decline_voting_rights"
+	ResetAccountOp              OpType = "reset_account"
+	SetResetAccountOp           OpType = "set_reset_account"
+	ClaimRewardBalanceOp        OpType = "claim_reward_balance"
+	DelegateVestingSharesOp     OpType = "delegate_vesting_shares"
+	AccountCreateWithDelegOp    OpType = "account_create_with_delegation"
+	WitnessSetPropertiesOp      OpType = "witness_set_properties"
+	AccountUpdate2Op            OpType = "account_update2"
+	CreateProposalOp            OpType = "create_proposal"
+	UpdateProposalVotesOp       OpType = "update_proposal_votes"
+	RemoveProposalOp            OpType = "remove_proposal"
+	UpdateProposalOp            OpType = "update_proposal"
+	CollateralizedConvertOp     OpType = "collateralized_convert"
+	RecurrentTransferOp         OpType = "recurrent_transfer"
+)
+
+// opIDs holds the id of every operation, in the order hived declares them.
+var opIDs = func() map[OpType]uint64 {
+	ops := []OpType{
+		VoteOp, CommentOp, TransferOp, TransferToVestingOp, WithdrawVestingOp,
+		LimitOrderCreateOp, LimitOrderCancelOp, FeedPublishOp, ConvertOp, AccountCreateOp,
+		AccountUpdateOp, WitnessUpdateOp, AccountWitnessVoteOp, AccountWitnessProxyOp, PowOp,
+		CustomOp, ReportOverProductionOp, DeleteCommentOp, CustomJSONOp, CommentOptionsOp,
+		SetWithdrawVestingRouteOp, LimitOrderCreate2Op, ClaimAccountOp, CreateClaimedAccountOp, RequestAccountRecoveryOp,
+		RecoverAccountOp, ChangeRecoveryAccountOp, EscrowTransferOp, EscrowDisputeOp, EscrowReleaseOp,
+		Pow2Op, EscrowApproveOp, TransferToSavingsOp, TransferFromSavingsOp, CancelTransferFromSavingsOp,
+		CustomBinaryOp, DeclineVotingRightsOp, ResetAccountOp, SetResetAccountOp, ClaimRewardBalanceOp,
+		DelegateVestingSharesOp, AccountCreateWithDelegOp, WitnessSetPropertiesOp, AccountUpdate2Op, CreateProposalOp,
+		UpdateProposalVotesOp, RemoveProposalOp, UpdateProposalOp, CollateralizedConvertOp, RecurrentTransferOp,
+	}
+	out := make(map[OpType]uint64, len(ops))
+	for i, op := range ops {
+		out[op] = uint64(i)
+	}
+	return out
+}()
+
+// ID returns the id of the operation in the binary encoding.
+func (t OpType) ID() (uint64, bool) {
+	id, ok := opIDs[t]
+	return id, ok
+}
+
+// Operation is an operation which can be put in a transaction.
+// It is implemented by the *Operation types of this package.
+type Operation interface {
+	// Type returns the name of the operation.
+	Type() OpType
+	// Validate checks the operation against the rules hived applies.
+	Validate() error
+	encode(e *encoder)
+}
+
+// Extensions are the future extensions of an operation. They encode to an empty list,
+// as none of the operations of this package use them yet.
+type Extensions []interface{}
+
+// MarshalJSON encodes nil extensions as an empty list.
+func (x Extensions) MarshalJSON() ([]byte, error) {
+	if x == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal([]interface{}(x))
+}
+
+// MarshalOperation validates op and encodes it in the condenser form, ["vote", {...}].
+func MarshalOperation(op Operation) ([]byte, error) {
+	if err := op.Validate(); err != nil {
+		return nil, err
+	}
+	return json.Marshal([]interface{}{op.Type(), op})
+}
+
+// EncodeOperation validates op and returns its binary encoding, as signed in transactions.
+func EncodeOperation(op Operation) ([]byte, error) {
+	if err := op.Validate(); err != nil {
+		return nil, err
+	}
+	id, ok := op.Type().ID()
+	if !ok {
+		return nil, fmt.Errorf("unknown operation %q", op.Type())
+	}
+
+	var e encoder
+	e.varint(id)
+	op.encode(&e)
+	if e.err != nil {
+		return nil, e.err
+	}
+	return e.buf.Bytes(), nil
+}
+
+// ErrUnsupportedOperation is returned by UnmarshalOperation for operations without a Go type.
+// Operations holding public keys or authorities, such as account_create, account_update,
+// witness_update, witness_set_properties and create_claimed_account, are not supported,
+// nor are the mining and account recovery operations.
+var ErrUnsupportedOperation = errors.New("unsupported operation")
+
+// operationTypes creates an empty operation for each name UnmarshalOperation decodes.
+var operationTypes = map[OpType]func() Operation{
+	VoteOp:                      func() Operation { return &VoteOperation{} },
+	CommentOp:                   func() Operation { return &CommentOperation{} },
+	TransferOp:                  func() Operation { return &TransferOperation{} },
+	TransferToVestingOp:         func() Operation { return &TransferToVestingOperation{} },
+	WithdrawVestingOp:           func() Operation { return &WithdrawVestingOperation{} },
+	LimitOrderCreateOp:          func() Operation { return &LimitOrderCreateOperation{} },
+	LimitOrderCancelOp:          func() Operation { return &LimitOrderCancelOperation{} },
+	ConvertOp:                   func() Operation { return &ConvertOperation{} },
+	AccountWitnessVoteOp:        func() Operation { return &AccountWitnessVoteOperation{} },
+	AccountWitnessProxyOp:       func() Operation { return &AccountWitnessProxyOperation{} },
+	DeleteCommentOp:             func() Operation { return &DeleteCommentOperation{} },
+	CustomJSONOp:                func() Operation { return &CustomJSONOperation{} },
+	CommentOptionsOp:            func() Operation { return &CommentOptionsOperation{} },
+	SetWithdrawVestingRouteOp:   func() Operation { return &SetWithdrawVestingRouteOperation{} },
+	TransferToSavingsOp:         func() Operation { return &TransferToSavingsOperation{} },
+	TransferFromSavingsOp:       func() Operation { return &TransferFromSavingsOperation{} },
+	CancelTransferFromSavingsOp: func() Operation { return &CancelTransferFromSavingsOperation{} },
+	ClaimRewardBalanceOp:        func() Operation { return &ClaimRewardBalanceOperation{} },
+	DelegateVestingSharesOp:     func() Operation { return &DelegateVestingSharesOperation{} },
+	AccountUpdate2Op:            func() Operation { return &AccountUpdate2Operation{} },
+	UpdateProposalVotesOp:       func() Operation { return &UpdateProposalVotesOperation{} },
+	CollateralizedConvertOp:     func() Operation { return &CollateralizedConvertOperation{} },
+	RecurrentTransferOp:         func() Operation { return &RecurrentTransferOperation{} },
+	FeedPublishOp:               func() Operation { return &FeedPublishOperation{} },
+	ClaimAccountOp:              func() Operation { return &ClaimAccountOperation{} },
+	ChangeRecoveryAccountOp:     func() Operation { return &ChangeRecoveryAccountOperation{} },
+	DeclineVotingRightsOp:       func() Operation { return &DeclineVotingRightsOperation{} },
+	CustomOp:                    func() Operation { return &CustomOperation{} },
+	LimitOrderCreate2Op:         func() Operation { return &LimitOrderCreate2Operation{} },
+	EscrowTransferOp:            func() Operation { return &EscrowTransferOperation{} },
+	EscrowApproveOp:             func() Operation { return &EscrowApproveOperation{} },
+	EscrowDisputeOp:             func() Operation { return &EscrowDisputeOperation{} },
+	EscrowReleaseOp:             func() Operation { return &EscrowReleaseOperation{} },
+	CreateProposalOp:            func() Operation { return &CreateProposalOperation{} },
+	UpdateProposalOp:            func() Operation { return &UpdateProposalOperation{} },
+	RemoveProposalOp:            func() Operation { return &RemoveProposalOperation{} },
+}
+
+// UnmarshalOperation decodes an operation in the condenser form, ["vote", {...}],
+// into a pointer to its type, such as *VoteOperation.
+func UnmarshalOperation(data []byte) (Operation, error) {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil || len(raw) != 2 {
+		return nil, fmt.Errorf("invalid operation %s", data)
+	}
+	var name OpType
+	if err := json.Unmarshal(raw[0], &name); err != nil {
+		return nil, fmt.Errorf("invalid operation name %s", raw[0])
+	}
+	newOp, ok := operationTypes[name]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnsupportedOperation, name)
+	}
+
+	op := newOp()
+	if err := json.Unmarshal(raw[1], op); err != nil {
+		return nil, fmt.Errorf("invalid %s operation: %v", name, err)
+	}
+	return op, nil
+}
+
+// CustomJSONOperation carries application data, such as follows or community
+// actions, in a transaction. JSON holds the encoded payload.
+type CustomJSONOperation struct {
+	RequiredAuths        []string `json:"required_auths"`
+	RequiredPostingAuths []string `json:"required_posting_auths"`
+	ID                   string   `json:"id"`
+	JSON                 string   `json:"json"`
+}
+
+// NewCustomJSON returns a custom_json operation with id and payload encoded to JSON.
+func NewCustomJSON(requiredAuths, requiredPostingAuths []string, id string, payload interface{}) (CustomJSONOperation, error) {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return CustomJSONOperation{}, err
+	}
+	if requiredAuths == nil {
+		requiredAuths = []string{}
+	}
+	if requiredPostingAuths == nil {
+		requiredPostingAuths = []string{}
+	}
+	op := CustomJSONOperation{RequiredAuths: requiredAuths, RequiredPostingAuths: requiredPostingAuths, ID: id, JSON: string(b)}
+	return op, op.Validate()
+}
+
+// Type returns CustomJSONOp.
+func (op CustomJSONOperation) Type() OpType { return CustomJSONOp }
+
+// Validate checks the auths, the id length and that JSON is valid.
+func (op CustomJSONOperation) Validate() error {
+	if len(op.RequiredAuths)+len(op.RequiredPostingAuths) == 0 {
+		return fmt.Errorf("custom_json needs at least one required auth")
+	}
+	if err := validateAccounts(append(append([]string{}, op.RequiredAuths...), op.RequiredPostingAuths...)...); err != nil {
+		return err
+	}
+	if len(op.ID) > maxCustomOpIDLength {
+		return fmt.Errorf("custom_json id %q is longer than %d characters", op.ID, maxCustomOpIDLength)
+	}
+	if op.JSON == "" {
+		return fmt.Errorf("custom_json json is empty")
+	}
+	return validateJSON("custom_json json", op.JSON)
+}
+
+func (op CustomJSONOperation) encode(e *encoder) {
+	e.stringSet(op.RequiredAuths)
+	e.stringSet(op.RequiredPostingAuths)
+	e.string(op.ID)
+	e.string(op.JSON)
+}
+
+// postingCustomJSON returns a custom_json operation with id, signed with the posting key of actor.
+// payload must encode to JSON; it is only ever built from strings by this package.
+func postingCustomJSON(actor, id string, payload interface{}) CustomJSONOperation {
+	b, _ := json.Marshal(payload)
+	return CustomJSONOperation{
+		RequiredAuths:        []string{},
+		RequiredPostingAuths: []string{actor},
+		ID:                   id,
+		JSON:                 string(b),
+	}
+}
@@ -0,0 +1,213 @@
+package gohive
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// PostMetadata is the decoded json_metadata of a post.
+// Nodes return it as a JSON encoded string or, from bridge, as an object;
+// both decode into the same fields and Raw keeps the original object.
+type PostMetadata struct {
+	Tags   []string
+	Image  []string
+	App    string
+	Format string
+	Raw    json.RawMessage
+}
+
+// MarshalJSON encodes the original metadata object.
+func (m PostMetadata) MarshalJSON() ([]byte, error) {
+	if len(m.Raw) == 0 {
+		return []byte("{}"), nil
+	}
+	return m.Raw, nil
+}
+
+// UnmarshalJSON decodes metadata given as an object or a JSON encoded string.
+// Metadata which is not valid JSON decodes to empty metadata, as apps write anything in it.
+func (m *PostMetadata) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		b = []byte(s)
+	}
+
+	var fields struct {
+		Tags   json.RawMessage `json:"tags"`
+		Image  json.RawMessage `json:"image"`
+		App    json.RawMessage `json:"app"`
+		Format json.RawMessage `json:"format"`
+	}
+	if err := json.Unmarshal(b, &fields); err != nil {
+		*m = PostMetadata{}
+		return nil
+	}
+
+	*m = PostMetadata{
+		Tags:   stringList(fields.Tags),
+		Image:  stringList(fields.Image),
+		App:    stringValue(fields.App),
+		Format: stringValue(fields.Format),
+		Raw:    append(json.RawMessage(nil), b...),
+	}
+	return nil
+}
+
+// stringList decodes a list of strings or a single string, dropping anything else.
+func stringList(raw json.RawMessage) []string {
+	var list []interface{}
+	if err := json.Unmarshal(raw, &list); err != nil {
+		if s := stringValue(raw); s != "" {
+			return []string{s}
+		}
+		return nil
+	}
+	out := []string{}
+	for _, v := range list {
+		if s, ok := v.(string); ok {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// stringValue decodes a string, returning "" for anything else.
+func stringValue(raw json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return ""
+	}
+	return s
+}
+
+// Beneficiary receives Weight basis points of the author rewards of a post.
+type Beneficiary struct {
+	Account string `json:"account"`
+	Weight  int    `json:"weight"`
+}
+
+// ActiveVote is a vote on a post. Percent is in basis points, negative for downvotes.
+// Bridge only returns Voter and Rshares.
+type ActiveVote struct {
+	Voter      string      `json:"voter"`
+	Weight     json.Number `json:"weight"`
+	Rshares    json.Number `json:"rshares"`
+	Percent    int         `json:"percent"`
+	Reputation json.Number `json:"reputation"`
+	Time       Time        `json:"time"`
+}
+
+// Post is a post or comment. It decodes both the condenser and bridge forms;
+// fields only returned by one of them are left empty by the other.
+type Post struct {
+	ID                      int64         `json:"id"`
+	PostID                  int64         `json:"post_id"`
+	Author                  string        `json:"author"`
+	Permlink                string        `json:"permlink"`
+	Category                string        `json:"category"`
+	Community               string        `json:"community"`
+	CommunityTitle          string        `json:"community_title"`
+	ParentAuthor            string        `json:"parent_author"`
+	ParentPermlink          string        `json:"parent_permlink"`
+	RootAuthor              string        `json:"root_author"`
+	RootPermlink            string        `json:"root_permlink"`
+	RootTitle               string        `json:"root_title"`
+	Title                   string        `json:"title"`
+	Body                    string        `json:"body"`
+	JSONMetadata            PostMetadata  `json:"json_metadata"`
+	URL                     string        `json:"url"`
+	Created                 Time          `json:"created"`
+	LastUpdate              Time          `json:"last_update"`
+	Updated                 Time          `json:"updated"`
+	Active                  Time          `json:"active"`
+	LastPayout              Time          `json:"last_payout"`
+	CashoutTime             Time          `json:"cashout_time"`
+	PayoutAt                Time          `json:"payout_at"`
+	Depth                   int           `json:"depth"`
+	Children                int           `json:"children"`
+	NetRshares              json.Number   `json:"net_rshares"`
+	AbsRshares              json.Number   `json:"abs_rshares"`
+	VoteRshares             json.Number   `json:"vote_rshares"`
+	NetVotes                int           `json:"net_votes"`
+	AuthorReputation        json.Number   `json:"author_reputation"`
+	IsPaidout               bool          `json:"is_paidout"`
+	Payout                  float64       `json:"payout"`
+	TotalPayoutValue        Asset         `json:"total_payout_value"`
+	AuthorPayoutValue       Asset         `json:"author_payout_value"`
+	CuratorPayoutValue      Asset         `json:"curator_payout_value"`
+	PendingPayoutValue      Asset         `json:"pending_payout_value"`
+	TotalPendingPayoutValue Asset         `json:"total_pending_payout_value"`
+	MaxAcceptedPayout       Asset         `json:"max_accepted_payout"`
+	PercentHbd              int           `json:"percent_hbd"`
+	AllowReplies            bool          `json:"allow_replies"`
+	AllowVotes              bool          `json:"allow_votes"`
+	AllowCurationRewards    bool          `json:"allow_curation_rewards"`
+	Beneficiaries           []Beneficiary `json:"beneficiaries"`
+	ActiveVotes             []ActiveVote  `json:"active_votes"`
+	RebloggedBy             []string      `json:"reblogged_by"`
+}
+
+// GetContent returns the post or comment at author/permlink.
+func (c *Client) GetContent(author, permlink string) (*Post, error) {
+	resp, err := c.getAccountData("get_content", author, permlink)
+	if err != nil {
+		return nil, err
+	}
+
+	var out Post
+	if err := resp.GetObject(&out); err != nil {
+		return nil, err
+	}
+	// Nodes return an empty post instead of an error for unknown permlinks.
+	if out.Author == "" {
+		return nil, fmt.Errorf("post @%s/%s not found", author, permlink)
+	}
+	return &out, nil
+}
+
+// GetContentReplies returns the direct replies to author/permlink.
+func (c *Client) GetContentReplies(author, permlink string) ([]Post, error) {
+	resp, err := c.getAccountData("get_content_replies", author, permlink)
+	if err != nil {
+		return nil, err
+	}
+
+	out := []Post{}
+	if err := resp.GetObject(&out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetActiveVotes returns the votes on author/permlink.
+func (c *Client) GetActiveVotes(author, permlink string) ([]ActiveVote, error) {
+	resp, err := c.getAccountData("get_active_votes", author, permlink)
+	if err != nil {
+		return nil, err
+	}
+
+	out := []ActiveVote{}
+	if err := resp.GetObject(&out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetPost returns author/permlink using bridge.get_post, as seen by observer.
+// observer may be empty.
+func (c *Client) GetPost(author, permlink, observer string) (*Post, error) {
+	resp, err := c.getAPIData("bridge.get_post", struct {
+		Author   string `json:"author"`
+		Permlink string `json:"permlink"`
+		Observer string `json:"observer,omitempty"`
+	}{author, permlink, observer})
+	if err != nil {
+		return nil, err
+	}
+
+	var out Post
+	if err := resp.GetObject(&out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
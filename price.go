@@ -0,0 +1,49 @@
+package gohive
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// Price is an exchange rate between two assets, such as a feed price of 0.250 HBD per 1.000 HIVE.
+type Price struct {
+	Base  Asset `json:"base"`
+	Quote Asset `json:"quote"`
+}
+
+// Convert converts a into the other asset of the price, rounding down.
+func (p Price) Convert(a Asset) (Asset, error) {
+	return p.convertWithFee(a, 0)
+}
+
+// convertWithFee converts a like Convert and increases the result by fee basis points.
+func (p Price) convertWithFee(a Asset, fee int64) (Asset, error) {
+	from, to := p.Base, p.Quote
+	if a.Symbol == p.Quote.Symbol {
+		from, to = p.Quote, p.Base
+	} else if a.Symbol != p.Base.Symbol {
+		return Asset{}, fmt.Errorf("cannot convert %s with a %s/%s price", a.Symbol, p.Base.Symbol, p.Quote.Symbol)
+	}
+	if from.Amount == 0 {
+		return Asset{}, fmt.Errorf("invalid price %s/%s", p.Base, p.Quote)
+	}
+
+	out := new(big.Int).Mul(big.NewInt(a.Amount), big.NewInt(to.Amount))
+	out.Mul(out, big.NewInt(percent100+fee))
+	out.Div(out, new(big.Int).Mul(big.NewInt(from.Amount), big.NewInt(percent100)))
+	return Asset{Amount: out.Int64(), Precision: to.Precision, Symbol: to.Symbol}, nil
+}
+
+// GetCurrentMedianHistoryPrice returns the median HBD/HIVE feed price used for conversions.
+func (c *Client) GetCurrentMedianHistoryPrice() (*Price, error) {
+	resp, err := c.getAccountData("get_current_median_history_price")
+	if err != nil {
+		return nil, err
+	}
+
+	var out Price
+	if err := resp.GetObject(&out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
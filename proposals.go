@@ -0,0 +1,191 @@
+package gohive
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// ProposalOrder is the sort order used by ListProposals.
+type ProposalOrder string
+
+// Orders accepted by list_proposals.
+const (
+	ProposalsByCreator    ProposalOrder = "by_creator"
+	ProposalsByStartDate  ProposalOrder = "by_start_date"
+	ProposalsByEndDate    ProposalOrder = "by_end_date"
+	ProposalsByTotalVotes ProposalOrder = "by_total_votes"
+)
+
+// ProposalVoteOrder is the sort order used by ListProposalVotes.
+type ProposalVoteOrder string
+
+// Orders accepted by list_proposal_votes.
+const (
+	ProposalVotesByVoterProposal ProposalVoteOrder = "by_voter_proposal"
+	ProposalVotesByProposalVoter ProposalVoteOrder = "by_proposal_voter"
+)
+
+// OrderDirection is the direction of a list.
+type OrderDirection string
+
+// Directions accepted by the list methods.
+const (
+	Ascending  OrderDirection = "ascending"
+	Descending OrderDirection = "descending"
+)
+
+// ProposalStatus filters proposals by their state.
+type ProposalStatus string
+
+// Statuses accepted by list_proposals and list_proposal_votes.
+const (
+	ProposalsAll      ProposalStatus = "all"
+	ProposalsInactive ProposalStatus = "inactive"
+	ProposalsActive   ProposalStatus = "active"
+	ProposalsExpired  ProposalStatus = "expired"
+	ProposalsVotable  ProposalStatus = "votable"
+)
+
+// ReturnProposalID is the id of the proposal paying back to the fund.
+// Only proposals with more votes than it are funded.
+const ReturnProposalID = 0
+
+// Proposal is a request for funding from the Decentralized Hive Fund.
+type Proposal struct {
+	ID         int64       `json:"id"`
+	ProposalID int64       `json:"proposal_id"`
+	Creator    string      `json:"creator"`
+	Receiver   string      `json:"receiver"`
+	StartDate  Time        `json:"start_date"`
+	EndDate    Time        `json:"end_date"`
+	DailyPay   Asset       `json:"daily_pay"`
+	Subject    string      `json:"subject"`
+	Permlink   string      `json:"permlink"`
+	TotalVotes json.Number `json:"total_votes"`
+	Status     string      `json:"status"`
+}
+
+// ProposalVote is the approval of a proposal by an account.
+type ProposalVote struct {
+	ID       int64    `json:"id"`
+	Voter    string   `json:"voter"`
+	Proposal Proposal `json:"proposal"`
+}
+
+// ListProposals returns up to limit proposals in order and direction, filtered by status.
+// start holds the value of order to begin at, e.g. []interface{}{""} for ProposalsByCreator
+// or []interface{}{"2020-01-01T00:00:00"} for ProposalsByStartDate.
+func (c *Client) ListProposals(order ProposalOrder, direction OrderDirection, status ProposalStatus, start interface{}, limit int) ([]Proposal, error) {
+	if limit < 1 || limit > maxListLimit {
+		return nil, fmt.Errorf("method ListProposals needs a limit between 1 and %d", maxListLimit)
+	}
+
+	resp, err := c.getAccountData("list_proposals", start, limit, order, direction, status)
+	if err != nil {
+		return nil, err
+	}
+
+	out := []Proposal{}
+	if err := resp.GetObject(&out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FindProposals returns the proposals with the ids passed in.
+func (c *Client) FindProposals(ids ...int64) ([]Proposal, error) {
+	if len(ids) < 1 || len(ids) > maxListLimit {
+		return nil, fmt.Errorf("method FindProposals needs between 1 and %d ids", maxListLimit)
+	}
+
+	resp, err := c.getAccountData("find_proposals", ids)
+	if err != nil {
+		return nil, err
+	}
+
+	out := []Proposal{}
+	if err := resp.GetObject(&out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListProposalVotes returns up to limit proposal votes in order and direction, filtered by status.
+// start is a [voter, proposal id] pair for ProposalVotesByVoterProposal and a
+// [proposal id, voter] pair for ProposalVotesByProposalVoter.
+func (c *Client) ListProposalVotes(order ProposalVoteOrder, direction OrderDirection, status ProposalStatus, start interface{}, limit int) ([]ProposalVote, error) {
+	if limit < 1 || limit > maxListLimit {
+		return nil, fmt.Errorf("method ListProposalVotes needs a limit between 1 and %d", maxListLimit)
+	}
+
+	resp, err := c.getAccountData("list_proposal_votes", start, limit, order, direction, status)
+	if err != nil {
+		return nil, err
+	}
+
+	out := []ProposalVote{}
+	if err := resp.GetObject(&out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ProposalFunding is the daily amount a proposal receives.
+type ProposalFunding struct {
+	Proposal Proposal
+	Paid     Asset
+}
+
+// SimulateFunding returns the proposals funded at now and what each receives per day.
+// fund is the HBD balance of the fund, of which 1% is paid out per day. Proposals
+// running at now are paid in order of votes until the budget runs out; those not
+// voted above the return proposal, when it is in proposals, get nothing.
+func SimulateFunding(proposals []Proposal, fund Asset, now time.Time) ([]ProposalFunding, error) {
+	votes := make(map[int64]int64, len(proposals))
+	threshold := int64(-1)
+	for _, p := range proposals {
+		v, err := strconv.ParseInt(p.TotalVotes.String(), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid total votes %q of proposal %d", p.TotalVotes, p.ProposalID)
+		}
+		votes[p.ProposalID] = v
+		if p.ProposalID == ReturnProposalID {
+			threshold = v
+		}
+	}
+
+	running := []Proposal{}
+	for _, p := range proposals {
+		if p.ProposalID == ReturnProposalID || votes[p.ProposalID] <= threshold {
+			continue
+		}
+		if now.Before(p.StartDate.Time) || !now.Before(p.EndDate.Time) {
+			continue
+		}
+		running = append(running, p)
+	}
+	sort.SliceStable(running, func(i, j int) bool {
+		if votes[running[i].ProposalID] != votes[running[j].ProposalID] {
+			return votes[running[i].ProposalID] > votes[running[j].ProposalID]
+		}
+		return running[i].ProposalID < running[j].ProposalID
+	})
+
+	budget := fund.Amount / 100
+	out := []ProposalFunding{}
+	for _, p := range running {
+		if budget <= 0 {
+			break
+		}
+		paid := p.DailyPay
+		if paid.Amount > budget {
+			paid.Amount = budget
+		}
+		budget -= paid.Amount
+		out = append(out, ProposalFunding{Proposal: p, Paid: paid})
+	}
+	return out, nil
+}
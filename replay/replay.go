@@ -0,0 +1,221 @@
+// Package replay records JSON-RPC requests and responses to fixture files and
+// replays them later, so tests can use real node payloads without network access.
+// Example:
+// c := gohive.NewClient()
+// rec := replay.NewRecorder(c.Client)
+// c.Client = rec
+// c.GetAccounts("jrswab")
+// rec.Save("testdata/accounts.json")
+//
+// r, err := replay.Load("testdata/accounts.json")
+// r.Strict = true
+// c.Client = r
+package replay
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	gohive "github.com/nathansenn/go-hive"
+	rpc "github.com/ybbus/jsonrpc"
+)
+
+// Interaction is one recorded request and the response the node gave.
+type Interaction struct {
+	Method   string           `json:"method"`
+	Params   json.RawMessage  `json:"params,omitempty"`
+	Response *rpc.RPCResponse `json:"response"`
+}
+
+// Fixture is the content of a fixture file.
+type Fixture struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Recorder is a gohive.Caller which passes requests on to Next and records
+// every successful round trip.
+type Recorder struct {
+	Next gohive.Caller
+
+	mu      sync.Mutex
+	fixture Fixture
+}
+
+// NewRecorder creates a Recorder which sends requests through next.
+func NewRecorder(next gohive.Caller) *Recorder {
+	return &Recorder{Next: next}
+}
+
+// CallRaw sends req through Next and records the response.
+func (r *Recorder) CallRaw(req *rpc.RPCRequest) (*rpc.RPCResponse, error) {
+	return r.CallRawContext(context.Background(), req)
+}
+
+// CallRawContext is CallRaw passing ctx on to Next.
+func (r *Recorder) CallRawContext(ctx context.Context, req *rpc.RPCRequest) (*rpc.RPCResponse, error) {
+	resp, err := gohive.CallContext(ctx, r.Next, req)
+	if err != nil || resp == nil {
+		return resp, err
+	}
+
+	params, err := canonicalParams(req.Params)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	r.fixture.Interactions = append(r.fixture.Interactions, Interaction{
+		Method:   req.Method,
+		Params:   params,
+		Response: resp,
+	})
+	r.mu.Unlock()
+	return resp, nil
+}
+
+// Interactions returns the round trips recorded so far.
+func (r *Recorder) Interactions() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Interaction(nil), r.fixture.Interactions...)
+}
+
+// Save writes the recorded round trips to a fixture file at path.
+func (r *Recorder) Save(path string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	b, err := json.MarshalIndent(r.fixture, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(b, '\n'), 0644)
+}
+
+// Replayer is a gohive.Caller which answers requests from recorded interactions,
+// matching on method and params. Each interaction is used once, in recorded order;
+// once all matching interactions are used the last one keeps answering.
+// In Strict mode unrecorded requests fail, and repeated requests need one recording each.
+// Otherwise unrecorded requests are passed to Next when it is set.
+type Replayer struct {
+	Strict bool
+	Next   gohive.Caller
+
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+// NewReplayer creates a Replayer answering from interactions.
+func NewReplayer(interactions []Interaction) *Replayer {
+	return &Replayer{
+		interactions: interactions,
+		used:         make([]bool, len(interactions)),
+	}
+}
+
+// Load reads a fixture file written by Recorder.Save.
+func Load(path string) (*Replayer, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var f Fixture
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(&f); err != nil {
+		return nil, fmt.Errorf("replay fixture %v: %v", path, err)
+	}
+
+	for i, in := range f.Interactions {
+		params, err := canonicalParams(in.Params)
+		if err != nil {
+			return nil, fmt.Errorf("replay fixture %v: %v", path, err)
+		}
+		f.Interactions[i].Params = params
+	}
+	return NewReplayer(f.Interactions), nil
+}
+
+// CallRaw returns the recorded response for req.
+func (r *Replayer) CallRaw(req *rpc.RPCRequest) (*rpc.RPCResponse, error) {
+	return r.CallRawContext(context.Background(), req)
+}
+
+// CallRawContext is CallRaw passing ctx on to Next for unrecorded requests.
+func (r *Replayer) CallRawContext(ctx context.Context, req *rpc.RPCRequest) (*rpc.RPCResponse, error) {
+	params, err := canonicalParams(req.Params)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp, ok := r.match(req.Method, params); ok {
+		out := *resp
+		out.ID = req.ID
+		return &out, nil
+	}
+
+	if !r.Strict && r.Next != nil {
+		return gohive.CallContext(ctx, r.Next, req)
+	}
+	return nil, fmt.Errorf("replay: no recorded response for %v %s", req.Method, params)
+}
+
+// match finds the next unused interaction for method and params.
+func (r *Replayer) match(method string, params json.RawMessage) (*rpc.RPCResponse, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	last := -1
+	for i, in := range r.interactions {
+		if in.Method != method || !bytes.Equal(in.Params, params) {
+			continue
+		}
+		if !r.used[i] {
+			r.used[i] = true
+			return in.Response, true
+		}
+		last = i
+	}
+
+	if last < 0 || r.Strict {
+		return nil, false
+	}
+	return r.interactions[last].Response, true
+}
+
+// Unused returns the interactions which have not been replayed yet.
+func (r *Replayer) Unused() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var out []Interaction
+	for i, in := range r.interactions {
+		if !r.used[i] {
+			out = append(out, in)
+		}
+	}
+	return out
+}
+
+// canonicalParams encodes params with sorted object keys so equal params
+// built in Go and read from a fixture compare equal.
+func canonicalParams(params interface{}) (json.RawMessage, error) {
+	b, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+
+	var v interface{}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
@@ -0,0 +1,59 @@
+package gohive
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+)
+
+// ParseReputation parses a raw reputation of any size.
+func ParseReputation(n json.Number) (*big.Int, error) {
+	raw, ok := new(big.Int).SetString(n.String(), 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid reputation %q", n)
+	}
+	return raw, nil
+}
+
+// ReputationScore converts a raw reputation into the score shown by Hive front ends,
+// where new accounts start at 25.
+func ReputationScore(raw int64) float64 {
+	return ReputationScoreBig(big.NewInt(raw))
+}
+
+// ReputationScoreBig is ReputationScore for raw reputations which do not fit an int64.
+func ReputationScoreBig(raw *big.Int) float64 {
+	if raw.Sign() == 0 {
+		return 25
+	}
+
+	// log10 of the leading digits plus the number of dropped digits keeps
+	// full float precision for values of any size.
+	digits := new(big.Int).Abs(raw).String()
+	lead := digits
+	if len(lead) > 15 {
+		lead = lead[:15]
+	}
+	f, _ := strconv.ParseFloat(lead, 64)
+	score := math.Log10(f) + float64(len(digits)-len(lead))
+
+	score = math.Max(score-9, 0)
+	if raw.Sign() < 0 {
+		score = -score
+	}
+	return score*9 + 25
+}
+
+// ReputationScore returns the score of the account's reputation.
+func (a AccountData) ReputationScore() (float64, error) {
+	if a.Reputation == "" {
+		return 25, nil
+	}
+	raw, err := ParseReputation(a.Reputation)
+	if err != nil {
+		return 0, err
+	}
+	return ReputationScoreBig(raw), nil
+}
@@ -0,0 +1,246 @@
+package gohive
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// ManaRegenerationSeconds is the time a manabar takes to fill up from empty.
+const ManaRegenerationSeconds = 5 * 24 * 60 * 60
+
+// VoteDustThreshold is the rshares subtracted from every vote.
+const VoteDustThreshold = 50000000
+
+// votePowerReserveRate and the regeneration time make a full vote use 2% of the mana.
+const votePowerReserveRate = 10
+
+// Manabar is a regenerating resource, such as the voting mana of an account.
+// CurrentMana is in the smallest unit of VESTS as of LastUpdateTime, a unix time.
+type Manabar struct {
+	CurrentMana    json.Number `json:"current_mana,omitempty"`
+	LastUpdateTime int64       `json:"last_update_time"`
+}
+
+// Current returns the mana at now, regenerated towards max since the last update.
+func (m Manabar) Current(max int64, now time.Time) (int64, error) {
+	mana := new(big.Int)
+	if m.CurrentMana != "" {
+		if _, ok := mana.SetString(m.CurrentMana.String(), 10); !ok {
+			return 0, fmt.Errorf("invalid current mana %q", m.CurrentMana)
+		}
+	}
+	if elapsed := now.Unix() - m.LastUpdateTime; elapsed > 0 {
+		regen := new(big.Int).Mul(big.NewInt(max), big.NewInt(elapsed))
+		mana.Add(mana, regen.Div(regen, big.NewInt(ManaRegenerationSeconds)))
+	}
+	if mana.Cmp(big.NewInt(max)) > 0 {
+		return max, nil
+	}
+	return mana.Int64(), nil
+}
+
+// EffectiveVestingShares returns the vesting shares the account votes with:
+// its own minus delegated out, plus delegated in, minus the next power down payment.
+func (a AccountData) EffectiveVestingShares() (Asset, error) {
+	out, err := ParseAsset(a.VestingShares)
+	if err != nil {
+		return Asset{}, fmt.Errorf("invalid vesting shares: %v", err)
+	}
+	for _, v := range []struct {
+		value string
+		sign  int64
+	}{{a.DelegatedVestingShares, -1}, {a.ReceivedVestingShares, 1}} {
+		if v.value == "" {
+			continue
+		}
+		shares, err := ParseAsset(v.value)
+		if err != nil {
+			return Asset{}, fmt.Errorf("invalid vesting shares: %v", err)
+		}
+		out.Amount += v.sign * shares.Amount
+	}
+
+	if a.VestingWithdrawRate != "" {
+		rate, err := ParseAsset(a.VestingWithdrawRate)
+		if err != nil {
+			return Asset{}, fmt.Errorf("invalid vesting withdraw rate: %v", err)
+		}
+		next, err := a.remainingWithdraw()
+		if err != nil {
+			return Asset{}, err
+		}
+		if rate.Amount < next {
+			next = rate.Amount
+		}
+		if next > 0 {
+			out.Amount -= next
+		}
+	}
+	return out, nil
+}
+
+// VoteRshares returns the rshares of a vote by the account at weight, in basis
+// points from -10000 to 10000, cast at now.
+func (a AccountData) VoteRshares(weight int, now time.Time) (int64, error) {
+	if weight < -percent100 || weight > percent100 {
+		return 0, fmt.Errorf("vote weight %d is not between -%d and %d", weight, percent100, percent100)
+	}
+	shares, err := a.EffectiveVestingShares()
+	if err != nil {
+		return 0, err
+	}
+	mana, err := a.VotingManabar.Current(shares.Amount, now)
+	if err != nil {
+		return 0, err
+	}
+
+	abs := int64(weight)
+	if abs < 0 {
+		abs = -abs
+	}
+	used := new(big.Int).Mul(big.NewInt(mana), big.NewInt(abs*60*60*24))
+	used.Div(used, big.NewInt(percent100))
+	denom := big.NewInt(votePowerReserveRate * ManaRegenerationSeconds)
+	used.Add(used, new(big.Int).Sub(denom, big.NewInt(1)))
+	used.Div(used, denom)
+
+	rshares := used.Int64() - VoteDustThreshold
+	if rshares < 0 {
+		return 0, nil
+	}
+	if weight < 0 {
+		return -rshares, nil
+	}
+	return rshares, nil
+}
+
+// RewardFund is a pool paying out post rewards.
+type RewardFund struct {
+	ID                     int64       `json:"id"`
+	Name                   string      `json:"name"`
+	RewardBalance          Asset       `json:"reward_balance"`
+	RecentClaims           json.Number `json:"recent_claims"`
+	LastUpdate             Time        `json:"last_update"`
+	ContentConstant        json.Number `json:"content_constant"`
+	PercentCurationRewards int         `json:"percent_curation_rewards"`
+	PercentContentRewards  int         `json:"percent_content_rewards"`
+	AuthorRewardCurve      string      `json:"author_reward_curve"`
+	CurationRewardCurve    string      `json:"curation_reward_curve"`
+}
+
+// GetRewardFund returns the reward fund name, which is "post" on Hive.
+func (c *Client) GetRewardFund(name string) (*RewardFund, error) {
+	resp, err := c.getAccountData("get_reward_fund", name)
+	if err != nil {
+		return nil, err
+	}
+
+	var out RewardFund
+	if err := resp.GetObject(&out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// RsharesToHive returns the HIVE paid out for rshares under the linear reward curve.
+// Negative rshares pay nothing.
+func (f RewardFund) RsharesToHive(rshares int64) (Asset, error) {
+	claims, ok := new(big.Int).SetString(f.RecentClaims.String(), 10)
+	if !ok || claims.Sign() <= 0 {
+		return Asset{}, fmt.Errorf("invalid recent claims %q", f.RecentClaims)
+	}
+	out := f.RewardBalance
+	if rshares <= 0 {
+		out.Amount = 0
+		return out, nil
+	}
+
+	hive := new(big.Int).Mul(big.NewInt(rshares), big.NewInt(f.RewardBalance.Amount))
+	out.Amount = hive.Div(hive, claims).Int64()
+	return out, nil
+}
+
+// RsharesToHBD returns the HBD value of rshares at the median price.
+func (f RewardFund) RsharesToHBD(rshares int64, median Price) (Asset, error) {
+	hive, err := f.RsharesToHive(rshares)
+	if err != nil {
+		return Asset{}, err
+	}
+	return median.Convert(hive)
+}
+
+// VoteValue returns the HBD value of a vote by acc at weight, cast at now.
+func VoteValue(acc AccountData, weight int, now time.Time, fund RewardFund, median Price) (Asset, error) {
+	rshares, err := acc.VoteRshares(weight, now)
+	if err != nil {
+		return Asset{}, err
+	}
+	if rshares < 0 {
+		value, err := fund.RsharesToHBD(-rshares, median)
+		value.Amount = -value.Amount
+		return value, err
+	}
+	return fund.RsharesToHBD(rshares, median)
+}
+
+// BeneficiaryPayout is the part of a payout going to a beneficiary.
+type BeneficiaryPayout struct {
+	Account string
+	Amount  Asset
+}
+
+// PayoutEstimate splits the estimated payout of a post. All amounts are in HBD.
+// The author part is paid as AuthorHBD in HBD and AuthorHP in Hive Power.
+type PayoutEstimate struct {
+	Total         Asset
+	Curators      Asset
+	Beneficiaries []BeneficiaryPayout
+	Author        Asset
+	AuthorHBD     Asset
+	AuthorHP      Asset
+}
+
+// EstimatePayout estimates the pending payout of p from the rshares of its active votes.
+// The total is capped at the max accepted payout of the post.
+func EstimatePayout(p Post, fund RewardFund, median Price) (PayoutEstimate, error) {
+	var rshares int64
+	for _, v := range p.ActiveVotes {
+		r, err := v.Rshares.Int64()
+		if err != nil {
+			return PayoutEstimate{}, fmt.Errorf("invalid rshares %q of vote by %s", v.Rshares, v.Voter)
+		}
+		rshares += r
+	}
+
+	total, err := fund.RsharesToHBD(rshares, median)
+	if err != nil {
+		return PayoutEstimate{}, err
+	}
+	if p.MaxAcceptedPayout.Symbol == total.Symbol && total.Amount > p.MaxAcceptedPayout.Amount {
+		total.Amount = p.MaxAcceptedPayout.Amount
+	}
+
+	part := func(amount int64, percent int) Asset {
+		out := total
+		out.Amount = amount * int64(percent) / percent100
+		return out
+	}
+	out := PayoutEstimate{Total: total, Beneficiaries: []BeneficiaryPayout{}}
+	out.Curators = part(total.Amount, fund.PercentCurationRewards)
+	author := total.Amount - out.Curators.Amount
+
+	out.Author = total
+	out.Author.Amount = author
+	for _, b := range p.Beneficiaries {
+		paid := part(author, b.Weight)
+		out.Author.Amount -= paid.Amount
+		out.Beneficiaries = append(out.Beneficiaries, BeneficiaryPayout{Account: b.Account, Amount: paid})
+	}
+	// percent_hbd applies to half of the author reward, the other half is always Hive Power.
+	out.AuthorHBD = part(out.Author.Amount, p.PercentHbd/2)
+	out.AuthorHP = out.Author
+	out.AuthorHP.Amount -= out.AuthorHBD.Amount
+	return out, nil
+}
@@ -0,0 +1,75 @@
+package gohive
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// secondsPerYear is the year length hived uses for HBD interest.
+const secondsPerYear = 60 * 60 * 24 * 365
+
+// SavingsWithdraw is a pending withdrawal from savings, paid out at Complete.
+type SavingsWithdraw struct {
+	ID        int64  `json:"id"`
+	From      string `json:"from"`
+	To        string `json:"to"`
+	Memo      string `json:"memo"`
+	RequestID uint32 `json:"request_id"`
+	Amount    Asset  `json:"amount"`
+	Complete  Time   `json:"complete"`
+}
+
+// GetSavingsWithdrawFrom returns the pending savings withdrawals made by account.
+func (c *Client) GetSavingsWithdrawFrom(account string) ([]SavingsWithdraw, error) {
+	return c.getSavingsWithdrawals("get_savings_withdraw_from", account)
+}
+
+// GetSavingsWithdrawTo returns the pending savings withdrawals paying to account.
+func (c *Client) GetSavingsWithdrawTo(account string) ([]SavingsWithdraw, error) {
+	return c.getSavingsWithdrawals("get_savings_withdraw_to", account)
+}
+
+func (c *Client) getSavingsWithdrawals(method, account string) ([]SavingsWithdraw, error) {
+	resp, err := c.getAccountData(method, account)
+	if err != nil {
+		return nil, err
+	}
+
+	out := []SavingsWithdraw{}
+	if err := resp.GetObject(&out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SavingsInterest projects the HBD interest the account would be paid at now,
+// given the hbd_interest_rate of DynamicGlobalProperties in basis points.
+// It follows hived: the HBD seconds accrued since the last update are added to
+// SavingsHbdSeconds, and the total is scaled by the yearly rate.
+func (a AccountData) SavingsInterest(rate int, now time.Time) (Asset, error) {
+	balance, err := ParseAsset(a.SavingsHbdBalance)
+	if err != nil {
+		return Asset{}, fmt.Errorf("invalid savings hbd balance: %v", err)
+	}
+
+	seconds := new(big.Int)
+	if a.SavingsHbdSeconds != "" {
+		if _, ok := seconds.SetString(a.SavingsHbdSeconds, 10); !ok {
+			return Asset{}, fmt.Errorf("invalid savings hbd seconds %q", a.SavingsHbdSeconds)
+		}
+	}
+
+	lastUpdate, err := parseTime(a.SavingsHbdSecondsLastUpdate)
+	if err != nil {
+		return Asset{}, fmt.Errorf("invalid savings hbd seconds last update: %v", err)
+	}
+	if elapsed := int64(now.Sub(lastUpdate) / time.Second); elapsed > 0 {
+		seconds.Add(seconds, new(big.Int).Mul(big.NewInt(balance.Amount), big.NewInt(elapsed)))
+	}
+
+	interest := seconds.Div(seconds, big.NewInt(secondsPerYear))
+	interest.Mul(interest, big.NewInt(int64(rate)))
+	interest.Div(interest, big.NewInt(percent100))
+	return Asset{Amount: interest.Int64(), Precision: balance.Precision, Symbol: balance.Symbol}, nil
+}
@@ -0,0 +1,114 @@
+package gohive
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"sort"
+)
+
+// binarySymbols maps asset symbols to the names used by the binary encoding,
+// which kept the names from before the chain was renamed.
+var binarySymbols = map[string]string{
+	HIVE:  "STEEM",
+	HBD:   "SBD",
+	VESTS: "VESTS",
+}
+
+// encoder writes the binary encoding hived uses when signing transactions.
+// The first error is kept and later writes are ignored.
+type encoder struct {
+	buf bytes.Buffer
+	err error
+}
+
+func (e *encoder) uint8(v uint8) {
+	e.buf.WriteByte(v)
+}
+
+func (e *encoder) uint16(v uint16) {
+	var b [2]byte
+	binary.LittleEndian.PutUint16(b[:], v)
+	e.buf.Write(b[:])
+}
+
+func (e *encoder) int16(v int16) {
+	e.uint16(uint16(v))
+}
+
+func (e *encoder) uint32(v uint32) {
+	var b [4]byte
+	binary.LittleEndian.PutUint32(b[:], v)
+	e.buf.Write(b[:])
+}
+
+func (e *encoder) int64(v int64) {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], uint64(v))
+	e.buf.Write(b[:])
+}
+
+func (e *encoder) varint(v uint64) {
+	var b [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(b[:], v)
+	e.buf.Write(b[:n])
+}
+
+func (e *encoder) string(s string) {
+	e.varint(uint64(len(s)))
+	e.buf.WriteString(s)
+}
+
+func (e *encoder) bool(v bool) {
+	if v {
+		e.uint8(1)
+		return
+	}
+	e.uint8(0)
+}
+
+func (e *encoder) asset(a Asset) {
+	symbol, ok := binarySymbols[a.Symbol]
+	if !ok {
+		if e.err == nil {
+			e.err = fmt.Errorf("cannot encode asset symbol %q", a.Symbol)
+		}
+		return
+	}
+	e.int64(a.Amount)
+	e.uint8(a.Precision)
+	var b [7]byte
+	copy(b[:], symbol)
+	e.buf.Write(b[:])
+}
+
+func (e *encoder) time(t Time) {
+	e.uint32(uint32(t.Unix()))
+}
+
+// stringSet writes a flat_set, which hived keeps sorted.
+func (e *encoder) stringSet(set []string) {
+	sorted := append([]string{}, set...)
+	sort.Strings(sorted)
+	e.varint(uint64(len(sorted)))
+	for _, s := range sorted {
+		e.string(s)
+	}
+}
+
+// int64Set writes a flat_set of int64, which hived keeps sorted.
+func (e *encoder) int64Set(set []int64) {
+	sorted := append([]int64{}, set...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	e.varint(uint64(len(sorted)))
+	for _, v := range sorted {
+		e.int64(v)
+	}
+}
+
+func (e *encoder) extensions(x Extensions) {
+	if len(x) > 0 && e.err == nil {
+		e.err = fmt.Errorf("cannot encode operation extensions")
+	}
+	e.varint(0)
+}
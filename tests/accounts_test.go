@@ -277,7 +277,7 @@ func TestChain_GetAccountReputation(t *testing.T) {
 		name    string
 		fields  fields
 		args    args
-		want    int
+		want    int64
 		wantErr bool
 	}{
 		{
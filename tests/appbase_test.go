@@ -0,0 +1,139 @@
+package gohive
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	h "github.com/nathansenn/go-hive"
+	"github.com/nathansenn/go-hive/hivetest"
+	"github.com/nathansenn/go-hive/mocks"
+	"github.com/stretchr/testify/mock"
+	rpc "github.com/ybbus/jsonrpc"
+)
+
+func TestChain_FindAccounts(t *testing.T) {
+	mockCall := new(mocks.Caller)
+	output := &rpc.RPCResponse{
+		JSONRPC: "2.0",
+		Result: map[string]interface{}{
+			"accounts": []interface{}{
+				map[string]interface{}{
+					"id":          1111,
+					"name":        "jrswab",
+					"balance":     map[string]interface{}{"amount": "1000", "precision": 3, "nai": "@@000000021"},
+					"hbd_balance": map[string]interface{}{"amount": "250", "precision": 3, "nai": "@@000000013"},
+				},
+			},
+		},
+		ID: 0,
+	}
+	output2 := &rpc.RPCResponse{
+		JSONRPC: "2.0",
+		Error:   &rpc.RPCError{Code: 500, Message: "some error"},
+		ID:      0,
+	}
+	mockCall.On("CallRaw", mock.MatchedBy(func(req *rpc.RPCRequest) bool {
+		return req.Method == "database_api.find_accounts"
+	})).Return(output, nil).Once()
+	mockCall.On("CallRaw", mock.Anything).Return(nil, fmt.Errorf("fake error message")).Once()
+	mockCall.On("CallRaw", mock.Anything).Return(output2, nil).Once()
+
+	tests := []struct {
+		name    string
+		acc     []string
+		want    *[]h.AccountData
+		wantErr bool
+	}{
+		{
+			name:    "Get single account",
+			acc:     []string{"jrswab"},
+			want:    &[]h.AccountData{{ID: 1111, Name: "jrswab", Balance: "1.000 HIVE", HbdBalance: "0.250 HBD"}},
+			wantErr: false,
+		},
+		{
+			name:    "Get call error message",
+			acc:     []string{"jrswab"},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Get responce error message",
+			acc:     []string{"jrswab"},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Empty args",
+			acc:     []string{},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &h.Client{
+				URL:    "https://api.hive.blog",
+				Client: mockCall,
+				API:    h.AppbaseAPI,
+			}
+			got, err := c.GetAccounts(tt.acc...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Chain.GetAccounts() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Chain.GetAccounts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChain_AppbaseAgainstCondenser(t *testing.T) {
+	s := hivetest.NewServer()
+	defer s.Close()
+	s.AddAccount(h.AccountData{
+		Name:              "jrswab",
+		Balance:           "1.000 HIVE",
+		HbdBalance:        "0.250 HBD",
+		SavingsHbdBalance: "10.000 HBD",
+		VestingShares:     "1234.567890 VESTS",
+		Reputation:        "1111",
+	})
+	s.AddHistory("jrswab", "transfer", map[string]interface{}{
+		"from": "jrswab", "to": "hiveio", "amount": "1.000 HIVE", "memo": "",
+	})
+
+	condenser := h.NewClient(s.URL)
+	appbase := h.NewClient(s.URL)
+	appbase.API = h.AppbaseAPI
+
+	wantAcc, err := condenser.GetAccounts("jrswab")
+	if err != nil {
+		t.Fatalf("Chain.GetAccounts() error = %v", err)
+	}
+	gotAcc, err := appbase.GetAccounts("jrswab")
+	if err != nil {
+		t.Fatalf("Chain.GetAccounts() appbase error = %v", err)
+	}
+	if !reflect.DeepEqual(gotAcc, wantAcc) {
+		t.Errorf("Chain.GetAccounts() appbase = %v, want %v", gotAcc, wantAcc)
+	}
+
+	wantHist, err := condenser.GetAccountHistory("jrswab", -1, 10)
+	if err != nil {
+		t.Fatalf("Chain.GetAccountHistory() error = %v", err)
+	}
+	gotHist, err := appbase.GetAccountHistory("jrswab", -1, 10)
+	if err != nil {
+		t.Fatalf("Chain.GetAccountHistory() appbase error = %v", err)
+	}
+	if !reflect.DeepEqual(gotHist, wantHist) {
+		t.Errorf("Chain.GetAccountHistory() appbase = %v, want %v", gotHist, wantHist)
+	}
+
+	gotRep, err := appbase.GetAccountReputation("jrswab")
+	if err != nil || gotRep != 1111 {
+		t.Errorf("Chain.GetAccountReputation() appbase = %v, %v, want 1111", gotRep, err)
+	}
+}
@@ -0,0 +1,97 @@
+package gohive
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	h "github.com/nathansenn/go-hive"
+)
+
+func TestParseAsset(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    h.Asset
+		wantErr bool
+	}{
+		{
+			name: "HIVE",
+			s:    "1.000 HIVE",
+			want: h.Asset{Amount: 1000, Precision: 3, Symbol: "HIVE"},
+		},
+		{
+			name: "VESTS",
+			s:    "123456.789012 VESTS",
+			want: h.Asset{Amount: 123456789012, Precision: 6, Symbol: "VESTS"},
+		},
+		{
+			name: "Negative",
+			s:    "-0.001 HBD",
+			want: h.Asset{Amount: -1, Precision: 3, Symbol: "HBD"},
+		},
+		{
+			name:    "Missing symbol",
+			s:       "1.000",
+			wantErr: true,
+		},
+		{
+			name:    "Not a number",
+			s:       "one HIVE",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := h.ParseAsset(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseAsset() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseAsset() = %v, want %v", got, tt.want)
+			}
+			if !tt.wantErr && got.String() != tt.s {
+				t.Errorf("Asset.String() = %v, want %v", got.String(), tt.s)
+			}
+		})
+	}
+}
+
+func TestAsset_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    h.Asset
+		wantErr bool
+	}{
+		{
+			name: "Legacy string",
+			data: `"0.250 HBD"`,
+			want: h.Asset{Amount: 250, Precision: 3, Symbol: "HBD"},
+		},
+		{
+			name: "NAI object",
+			data: `{"amount":"1000","precision":3,"nai":"@@000000021"}`,
+			want: h.Asset{Amount: 1000, Precision: 3, Symbol: "HIVE"},
+		},
+		{
+			name:    "Unknown NAI",
+			data:    `{"amount":"1000","precision":3,"nai":"@@000000000"}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got h.Asset
+			err := json.Unmarshal([]byte(tt.data), &got)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Asset.UnmarshalJSON() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Asset.UnmarshalJSON() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
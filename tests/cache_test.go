@@ -0,0 +1,260 @@
+package gohive
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	h "github.com/nathansenn/go-hive"
+	"github.com/nathansenn/go-hive/mocks"
+	"github.com/stretchr/testify/mock"
+	rpc "github.com/ybbus/jsonrpc"
+)
+
+func TestCachingCaller_CallRaw(t *testing.T) {
+	var number json.Number
+	number = "1111"
+	output := &rpc.RPCResponse{
+		JSONRPC: "2.0",
+		Result:  number,
+		ID:      0,
+	}
+	oldBlock := &rpc.RPCResponse{
+		JSONRPC: "2.0",
+		Result:  map[string]interface{}{"timestamp": "2020-04-01T00:00:00"},
+		ID:      0,
+	}
+	newBlock := &rpc.RPCResponse{
+		JSONRPC: "2.0",
+		Result:  map[string]interface{}{"timestamp": time.Now().UTC().Format(h.TimeLayout)},
+		ID:      0,
+	}
+
+	tests := []struct {
+		name      string
+		method    string
+		params    []interface{}
+		output    *rpc.RPCResponse
+		wantCalls int
+	}{
+		{
+			name:      "Cache config forever",
+			method:    "get_config",
+			params:    []interface{}{},
+			output:    output,
+			wantCalls: 1,
+		},
+		{
+			name:      "Cache namespaced method",
+			method:    "condenser_api.get_accounts",
+			params:    []interface{}{[]string{"jrswab"}},
+			output:    output,
+			wantCalls: 1,
+		},
+		{
+			name:      "Method without a rule is not cached",
+			method:    "get_account_count",
+			params:    []interface{}{},
+			output:    output,
+			wantCalls: 2,
+		},
+		{
+			name:      "Irreversible block is cached",
+			method:    "get_block",
+			params:    []interface{}{1},
+			output:    oldBlock,
+			wantCalls: 1,
+		},
+		{
+			name:      "Reversible block is not cached",
+			method:    "get_block",
+			params:    []interface{}{2},
+			output:    newBlock,
+			wantCalls: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCall := new(mocks.Caller)
+			mockCall.On("CallRaw", mock.Anything).Return(tt.output, nil)
+
+			c := h.NewCachingCaller(mockCall, h.NewLRUCache(10))
+			for i := 0; i < 2; i++ {
+				got, err := c.CallRaw(rpc.NewRequest(tt.method, tt.params))
+				if err != nil {
+					t.Errorf("CachingCaller.CallRaw() error = %v", err)
+					return
+				}
+				if !reflect.DeepEqual(got, tt.output) {
+					t.Errorf("CachingCaller.CallRaw() = %v, want %v", got, tt.output)
+				}
+			}
+			mockCall.AssertNumberOfCalls(t, "CallRaw", tt.wantCalls)
+		})
+	}
+}
+
+func TestCachingCaller_CallRawAppbase(t *testing.T) {
+	old := "2020-04-01T00:00:00"
+	recent := time.Now().UTC().Format(h.TimeLayout)
+	history := func(timestamp string) *rpc.RPCResponse {
+		return &rpc.RPCResponse{
+			JSONRPC: "2.0",
+			Result:  map[string]interface{}{"history": []interface{}{[]interface{}{5, map[string]interface{}{"timestamp": timestamp}}}},
+			ID:      0,
+		}
+	}
+
+	tests := []struct {
+		name      string
+		method    string
+		params    interface{}
+		output    *rpc.RPCResponse
+		wantCalls int
+	}{
+		{
+			name:      "Irreversible block is cached",
+			method:    "block_api.get_block",
+			params:    map[string]interface{}{"block_num": 1},
+			output:    &rpc.RPCResponse{JSONRPC: "2.0", Result: map[string]interface{}{"block": map[string]interface{}{"timestamp": old}}},
+			wantCalls: 1,
+		},
+		{
+			name:      "Reversible block is not cached",
+			method:    "block_api.get_block",
+			params:    map[string]interface{}{"block_num": 2},
+			output:    &rpc.RPCResponse{JSONRPC: "2.0", Result: map[string]interface{}{"block": map[string]interface{}{"timestamp": recent}}},
+			wantCalls: 2,
+		},
+		{
+			name:      "Missing block is not cached",
+			method:    "block_api.get_block",
+			params:    map[string]interface{}{"block_num": 3},
+			output:    &rpc.RPCResponse{JSONRPC: "2.0", Result: map[string]interface{}{}},
+			wantCalls: 2,
+		},
+		{
+			name:      "Irreversible history from a fixed start is cached",
+			method:    "account_history_api.get_account_history",
+			params:    map[string]interface{}{"account": "jrswab", "start": 5, "limit": 1},
+			output:    history(old),
+			wantCalls: 1,
+		},
+		{
+			name:      "Newest history is not cached",
+			method:    "account_history_api.get_account_history",
+			params:    map[string]interface{}{"account": "jrswab", "start": uint64(math.MaxUint64), "limit": 1},
+			output:    history(old),
+			wantCalls: 2,
+		},
+		{
+			name:      "Reversible history is not cached",
+			method:    "account_history_api.get_account_history",
+			params:    map[string]interface{}{"account": "jrswab", "start": 5, "limit": 1},
+			output:    history(recent),
+			wantCalls: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCall := new(mocks.Caller)
+			mockCall.On("CallRaw", mock.Anything).Return(tt.output, nil)
+
+			c := h.NewCachingCaller(mockCall, h.NewLRUCache(10))
+			for i := 0; i < 2; i++ {
+				if _, err := c.CallRaw(rpc.NewRequest(tt.method, tt.params)); err != nil {
+					t.Errorf("CachingCaller.CallRaw() error = %v", err)
+					return
+				}
+			}
+			mockCall.AssertNumberOfCalls(t, "CallRaw", tt.wantCalls)
+		})
+	}
+}
+
+func TestCachingCaller_CallRawError(t *testing.T) {
+	mockCall := new(mocks.Caller)
+	output := &rpc.RPCResponse{
+		JSONRPC: "2.0",
+		Error:   &rpc.RPCError{Code: 500, Message: "some error"},
+		ID:      0,
+	}
+	mockCall.On("CallRaw", mock.Anything).Return(output, nil)
+
+	c := h.NewCachingCaller(mockCall, h.NewLRUCache(10))
+	for i := 0; i < 2; i++ {
+		if _, err := c.CallRaw(rpc.NewRequest("get_config")); err != nil {
+			t.Errorf("CachingCaller.CallRaw() error = %v", err)
+		}
+	}
+	mockCall.AssertNumberOfCalls(t, "CallRaw", 2)
+}
+
+func TestLRUCache(t *testing.T) {
+	resp := &rpc.RPCResponse{JSONRPC: "2.0"}
+
+	tests := []struct {
+		name    string
+		size    int
+		keys    []string
+		ttl     time.Duration
+		get     string
+		wantOK  bool
+		wantLen int
+	}{
+		{
+			name:    "Get stored response",
+			size:    2,
+			keys:    []string{"a"},
+			ttl:     h.NoExpiry,
+			get:     "a",
+			wantOK:  true,
+			wantLen: 1,
+		},
+		{
+			name:    "Evict least recently used",
+			size:    2,
+			keys:    []string{"a", "b", "c"},
+			ttl:     h.NoExpiry,
+			get:     "a",
+			wantOK:  false,
+			wantLen: 2,
+		},
+		{
+			name:    "Expired response",
+			size:    2,
+			keys:    []string{"a"},
+			ttl:     time.Nanosecond,
+			get:     "a",
+			wantOK:  false,
+			wantLen: 0,
+		},
+		{
+			name:    "Zero size stores nothing",
+			size:    0,
+			keys:    []string{"a"},
+			ttl:     h.NoExpiry,
+			get:     "a",
+			wantOK:  false,
+			wantLen: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := h.NewLRUCache(tt.size)
+			for _, k := range tt.keys {
+				l.Set(k, resp, tt.ttl)
+			}
+			time.Sleep(time.Millisecond)
+
+			if _, ok := l.Get(tt.get); ok != tt.wantOK {
+				t.Errorf("LRUCache.Get() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if got := l.Len(); got != tt.wantLen {
+				t.Errorf("LRUCache.Len() = %v, want %v", got, tt.wantLen)
+			}
+		})
+	}
+}
@@ -1,13 +1,22 @@
 package gohive
 
 import (
+	"encoding/json"
 	"reflect"
 	"testing"
 
 	h "github.com/nathansenn/go-hive"
+	"github.com/stretchr/testify/mock"
 	rpc "github.com/ybbus/jsonrpc"
 )
 
+// emptyParams matches requests sending an empty params array, as hived expects
+// for methods without params.
+var emptyParams = mock.MatchedBy(func(req *rpc.RPCRequest) bool {
+	b, _ := json.Marshal(req.Params)
+	return string(b) == "[]"
+})
+
 func TestNewClient(t *testing.T) {
 	type args struct {
 		URL []string
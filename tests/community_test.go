@@ -0,0 +1,214 @@
+package gohive
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	h "github.com/nathansenn/go-hive"
+	"github.com/nathansenn/go-hive/mocks"
+	"github.com/stretchr/testify/mock"
+	rpc "github.com/ybbus/jsonrpc"
+)
+
+func TestChain_GetCommunity(t *testing.T) {
+	mockCall := new(mocks.Caller)
+	output := &rpc.RPCResponse{
+		JSONRPC: "2.0",
+		Result: map[string]interface{}{
+			"id":          1337,
+			"name":        "hive-123456",
+			"title":       "Go Devs",
+			"about":       "Go on Hive",
+			"lang":        "en",
+			"type_id":     1,
+			"subscribers": 42,
+			"created_at":  "2020-03-20 14:00:00",
+			"settings":    map[string]interface{}{"avatar_url": ""},
+			"context":     map[string]interface{}{"role": "admin", "subscribed": true, "title": "Founder"},
+			"team":        []interface{}{[]interface{}{"jrswab", "owner", "Founder"}},
+		},
+		ID: 0,
+	}
+	output2 := &rpc.RPCResponse{
+		JSONRPC: "2.0",
+		Result:  nil,
+		ID:      0,
+	}
+	mockCall.On("CallRaw", mock.Anything).Return(output, nil).Once()
+	mockCall.On("CallRaw", mock.Anything).Return(nil, fmt.Errorf("fake error message")).Once()
+	mockCall.On("CallRaw", mock.Anything).Return(output2, nil).Once()
+
+	tests := []struct {
+		name    string
+		want    *h.Community
+		wantErr bool
+	}{
+		{
+			name: "Get community",
+			want: &h.Community{
+				ID:          1337,
+				Name:        "hive-123456",
+				Title:       "Go Devs",
+				About:       "Go on Hive",
+				Lang:        "en",
+				TypeID:      1,
+				Subscribers: 42,
+				CreatedAt:   h.Time{Time: time.Date(2020, 3, 20, 14, 0, 0, 0, time.UTC)},
+				Settings:    map[string]interface{}{"avatar_url": ""},
+				Context:     h.CommunityContext{Role: h.RoleAdmin, Subscribed: true, Title: "Founder"},
+				Team:        []h.CommunityRole{{Account: "jrswab", Role: h.RoleOwner, Title: "Founder"}},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "Get call error message",
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Community not found",
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &h.Client{
+				URL:    "https://api.hive.blog",
+				Client: mockCall,
+			}
+			got, err := c.GetCommunity("hive-123456", "jrswab")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Chain.GetCommunity() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Chain.GetCommunity() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChain_ListSubscribers(t *testing.T) {
+	mockCall := new(mocks.Caller)
+	output := &rpc.RPCResponse{
+		JSONRPC: "2.0",
+		Result: []interface{}{
+			[]interface{}{"jrswab", "owner", "Founder", "2020-03-20 14:00:00"},
+			[]interface{}{"hiveio", "guest", nil, "2020-03-21 14:00:00"},
+		},
+		ID: 0,
+	}
+	mockCall.On("CallRaw", mock.Anything).Return(output, nil).Once()
+
+	tests := []struct {
+		name    string
+		limit   int
+		want    []h.Subscriber
+		wantErr bool
+	}{
+		{
+			name:  "Get subscribers",
+			limit: 100,
+			want: []h.Subscriber{
+				{Account: "jrswab", Role: h.RoleOwner, Title: "Founder", Subscribed: h.Time{Time: time.Date(2020, 3, 20, 14, 0, 0, 0, time.UTC)}},
+				{Account: "hiveio", Role: h.RoleGuest, Title: "", Subscribed: h.Time{Time: time.Date(2020, 3, 21, 14, 0, 0, 0, time.UTC)}},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "Limit too large",
+			limit:   101,
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &h.Client{
+				URL:    "https://api.hive.blog",
+				Client: mockCall,
+			}
+			got, err := c.ListSubscribers("hive-123456", "", tt.limit)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Chain.ListSubscribers() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Chain.ListSubscribers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChain_ListAllSubscriptions(t *testing.T) {
+	mockCall := new(mocks.Caller)
+	output := &rpc.RPCResponse{
+		JSONRPC: "2.0",
+		Result:  []interface{}{[]interface{}{"hive-123456", "Go Devs", "admin", "Founder"}},
+		ID:      0,
+	}
+	mockCall.On("CallRaw", mock.Anything).Return(output, nil).Once()
+
+	c := &h.Client{
+		URL:    "https://api.hive.blog",
+		Client: mockCall,
+	}
+	got, err := c.ListAllSubscriptions("jrswab")
+	if err != nil {
+		t.Fatalf("Chain.ListAllSubscriptions() error = %v", err)
+	}
+	want := []h.Subscription{{Community: "hive-123456", Title: "Go Devs", Role: h.RoleAdmin, TeamTitle: "Founder"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Chain.ListAllSubscriptions() = %v, want %v", got, want)
+	}
+}
+
+func TestCommunityOperations(t *testing.T) {
+	tests := []struct {
+		name string
+		op   h.CustomJSONOperation
+		json string
+	}{
+		{
+			name: "Subscribe",
+			op:   h.CommunitySubscribe("jrswab", "hive-123456"),
+			json: `["subscribe",{"community":"hive-123456"}]`,
+		},
+		{
+			name: "Set role",
+			op:   h.CommunitySetRole("jrswab", "hive-123456", "hiveio", h.RoleMod),
+			json: `["setRole",{"community":"hive-123456","account":"hiveio","role":"mod"}]`,
+		},
+		{
+			name: "Set user title",
+			op:   h.CommunitySetUserTitle("jrswab", "hive-123456", "hiveio", "Helper"),
+			json: `["setUserTitle",{"community":"hive-123456","account":"hiveio","title":"Helper"}]`,
+		},
+		{
+			name: "Mute post",
+			op:   h.CommunityMutePost("jrswab", "hive-123456", "spammer", "buy-now", "spam"),
+			json: `["mutePost",{"community":"hive-123456","account":"spammer","permlink":"buy-now","notes":"spam"}]`,
+		},
+		{
+			name: "Pin post",
+			op:   h.CommunityPinPost("jrswab", "hive-123456", "jrswab", "welcome"),
+			json: `["pinPost",{"community":"hive-123456","account":"jrswab","permlink":"welcome"}]`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.op.ID != "community" {
+				t.Errorf("operation id = %s, want community", tt.op.ID)
+			}
+			if !reflect.DeepEqual(tt.op.RequiredPostingAuths, []string{"jrswab"}) {
+				t.Errorf("operation posting auths = %v, want [jrswab]", tt.op.RequiredPostingAuths)
+			}
+			if tt.op.JSON != tt.json {
+				t.Errorf("operation json = %s, want %s", tt.op.JSON, tt.json)
+			}
+		})
+	}
+}
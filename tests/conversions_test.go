@@ -0,0 +1,204 @@
+package gohive
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	h "github.com/nathansenn/go-hive"
+	"github.com/nathansenn/go-hive/mocks"
+	"github.com/stretchr/testify/mock"
+	rpc "github.com/ybbus/jsonrpc"
+)
+
+var median = h.Price{
+	Base:  h.Asset{Amount: 250, Precision: 3, Symbol: h.HBD},
+	Quote: h.Asset{Amount: 1000, Precision: 3, Symbol: h.HIVE},
+}
+
+func TestChain_GetConversionRequests(t *testing.T) {
+	mockCall := new(mocks.Caller)
+	output := &rpc.RPCResponse{
+		JSONRPC: "2.0",
+		Result: []interface{}{
+			map[string]interface{}{
+				"id":              9,
+				"owner":           "jrswab",
+				"requestid":       1,
+				"amount":          "10.000 HBD",
+				"conversion_date": "2020-03-24T02:00:00",
+			},
+		},
+		ID: 0,
+	}
+	output2 := &rpc.RPCResponse{
+		JSONRPC: "2.0",
+		Error:   &rpc.RPCError{Code: 500, Message: "some error"},
+		ID:      0,
+	}
+	mockCall.On("CallRaw", mock.Anything).Return(output, nil).Once()
+	mockCall.On("CallRaw", mock.Anything).Return(nil, fmt.Errorf("fake error message")).Once()
+	mockCall.On("CallRaw", mock.Anything).Return(output2, nil).Once()
+
+	tests := []struct {
+		name    string
+		want    []h.ConversionRequest
+		wantErr bool
+	}{
+		{
+			name: "Get requests",
+			want: []h.ConversionRequest{{
+				ID:             9,
+				Owner:          "jrswab",
+				RequestID:      1,
+				Amount:         h.Asset{Amount: 10000, Precision: 3, Symbol: h.HBD},
+				ConversionDate: h.Time{Time: time.Date(2020, 3, 24, 2, 0, 0, 0, time.UTC)},
+			}},
+			wantErr: false,
+		},
+		{
+			name:    "Get call error message",
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Get responce error message",
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &h.Client{
+				URL:    "https://api.hive.blog",
+				Client: mockCall,
+			}
+			got, err := c.GetConversionRequests("jrswab")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Chain.GetConversionRequests() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Chain.GetConversionRequests() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChain_GetCollateralizedConversionRequests(t *testing.T) {
+	mockCall := new(mocks.Caller)
+	output := &rpc.RPCResponse{
+		JSONRPC: "2.0",
+		Result: []interface{}{
+			map[string]interface{}{
+				"id":                10,
+				"owner":             "jrswab",
+				"requestid":         2,
+				"collateral_amount": "100.000 HIVE",
+				"converted_amount":  "11.875 HBD",
+				"conversion_date":   "2020-03-24T02:00:00",
+			},
+		},
+		ID: 0,
+	}
+	mockCall.On("CallRaw", mock.Anything).Return(output, nil).Once()
+
+	c := &h.Client{
+		URL:    "https://api.hive.blog",
+		Client: mockCall,
+	}
+	got, err := c.GetCollateralizedConversionRequests("jrswab")
+	if err != nil {
+		t.Fatalf("Chain.GetCollateralizedConversionRequests() error = %v", err)
+	}
+	want := []h.CollateralizedConversionRequest{{
+		ID:               10,
+		Owner:            "jrswab",
+		RequestID:        2,
+		CollateralAmount: h.Asset{Amount: 100000, Precision: 3, Symbol: h.HIVE},
+		ConvertedAmount:  h.Asset{Amount: 11875, Precision: 3, Symbol: h.HBD},
+		ConversionDate:   h.Time{Time: time.Date(2020, 3, 24, 2, 0, 0, 0, time.UTC)},
+	}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Chain.GetCollateralizedConversionRequests() = %v, want %v", got, want)
+	}
+}
+
+func TestChain_GetCurrentMedianHistoryPrice(t *testing.T) {
+	mockCall := new(mocks.Caller)
+	output := &rpc.RPCResponse{
+		JSONRPC: "2.0",
+		Result:  map[string]interface{}{"base": "0.250 HBD", "quote": "1.000 HIVE"},
+		ID:      0,
+	}
+	mockCall.On("CallRaw", emptyParams).Return(output, nil).Once()
+
+	c := &h.Client{
+		URL:    "https://api.hive.blog",
+		Client: mockCall,
+	}
+	got, err := c.GetCurrentMedianHistoryPrice()
+	if err != nil {
+		t.Fatalf("Chain.GetCurrentMedianHistoryPrice() error = %v", err)
+	}
+	if !reflect.DeepEqual(*got, median) {
+		t.Errorf("Chain.GetCurrentMedianHistoryPrice() = %v, want %v", got, median)
+	}
+}
+
+func TestPrice_Convert(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      h.Asset
+		want    string
+		wantErr bool
+	}{
+		{name: "HBD to HIVE", in: h.NewAsset(10, h.HBD), want: "40.000 HIVE", wantErr: false},
+		{name: "HIVE to HBD", in: h.NewAsset(4, h.HIVE), want: "1.000 HBD", wantErr: false},
+		{name: "Unknown symbol", in: h.NewAsset(4, h.VESTS), want: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := median.Convert(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Price.Convert() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got.String() != tt.want {
+				t.Errorf("Price.Convert() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEstimateSettlement(t *testing.T) {
+	got, err := h.ConversionRequest{Amount: h.NewAsset(10, h.HBD)}.EstimateSettlement(median)
+	if err != nil || got.String() != "40.000 HIVE" {
+		t.Errorf("ConversionRequest.EstimateSettlement() = %v, %v, want 40.000 HIVE", got, err)
+	}
+
+	tests := []struct {
+		name       string
+		collateral float64
+		want       string
+	}{
+		{name: "Excess collateral returned", collateral: 100, want: "50.125 HIVE"},
+		{name: "Collateral falls short", collateral: 10, want: "0.000 HIVE"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := h.CollateralizedConversionRequest{
+				CollateralAmount: h.NewAsset(tt.collateral, h.HIVE),
+				ConvertedAmount:  h.NewAsset(11.875, h.HBD),
+			}
+			got, err := r.EstimateSettlement(median)
+			if err != nil {
+				t.Fatalf("CollateralizedConversionRequest.EstimateSettlement() error = %v", err)
+			}
+			if got.String() != tt.want {
+				t.Errorf("CollateralizedConversionRequest.EstimateSettlement() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
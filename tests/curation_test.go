@@ -0,0 +1,97 @@
+package gohive
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	h "github.com/nathansenn/go-hive"
+)
+
+func TestCurationWeight(t *testing.T) {
+	tests := []struct {
+		name    string
+		elapsed time.Duration
+		rshares int64
+		weight  int64
+		max     int64
+	}{
+		{name: "Reverse auction", elapsed: 150 * time.Second, rshares: 1000, weight: 500, max: 1000},
+		{name: "Early window", elapsed: time.Hour, rshares: 1000, weight: 1000, max: 1000},
+		{name: "Mid window", elapsed: 30 * time.Hour, rshares: 1000, weight: 500, max: 500},
+		{name: "Late", elapsed: 80 * time.Hour, rshares: 1000, weight: 125, max: 125},
+		{name: "Downvote", elapsed: time.Hour, rshares: -1000, weight: 0, max: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			weight, max := h.CurationWeight(tt.elapsed, tt.rshares)
+			if weight != tt.weight || max != tt.max {
+				t.Errorf("CurationWeight() = %d, %d, want %d, %d", weight, max, tt.weight, tt.max)
+			}
+		})
+	}
+}
+
+func TestCurationRewards(t *testing.T) {
+	created := time.Date(2020, 3, 20, 0, 0, 0, 0, time.UTC)
+	votes := []h.CurationVote{
+		{Voter: "a", Rshares: 1000, Time: created.Add(150 * time.Second)},
+		{Voter: "b", Rshares: 1000, Time: created.Add(time.Hour)},
+		{Voter: "c", Rshares: 2000, Time: created.Add(30 * time.Hour)},
+	}
+	got := h.CurationRewards(created, votes, h.NewAsset(3, h.HIVE))
+	want := []h.CurationReward{
+		{Voter: "a", Reward: h.NewAsset(0.5, h.HIVE)},
+		{Voter: "b", Reward: h.NewAsset(1, h.HIVE)},
+		{Voter: "c", Reward: h.NewAsset(1, h.HIVE)},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CurationRewards() = %v, want %v", got, want)
+	}
+}
+
+func TestEstimateCurationReward(t *testing.T) {
+	created := time.Date(2020, 3, 20, 0, 0, 0, 0, time.UTC)
+	existing := []h.CurationVote{{Voter: "b", Rshares: 20000000000, Time: created.Add(time.Hour)}}
+	vote := h.CurationVote{Voter: "c", Rshares: 20000000000, Time: created.Add(2 * time.Hour)}
+
+	got, err := h.EstimateCurationReward(created, existing, vote, postFund)
+	if err != nil {
+		t.Fatalf("EstimateCurationReward() error = %v", err)
+	}
+	if got.String() != "1.000 HIVE" {
+		t.Errorf("EstimateCurationReward() = %v, want 1.000 HIVE", got)
+	}
+}
+
+func TestReconcileCurationRewards(t *testing.T) {
+	props := h.DynamicGlobalProperties{
+		TotalVestingFundHive: h.NewAsset(180000000, h.HIVE),
+		TotalVestingShares:   h.NewAsset(320000000000, h.VESTS),
+	}
+	predicted := []h.CurationReward{
+		{Voter: "a", Reward: h.NewAsset(0.5, h.HIVE)},
+		{Voter: "b", Reward: h.NewAsset(0.6, h.HIVE)},
+	}
+	actual := []h.CurationRewardOperation{
+		{Curator: "a", Reward: h.NewAsset(1000, h.VESTS)},
+		{Curator: "c", Reward: h.NewAsset(2000, h.VESTS)},
+	}
+
+	got, err := h.ReconcileCurationRewards(predicted, actual, props.VestingPrice())
+	if err != nil {
+		t.Fatalf("ReconcileCurationRewards() error = %v", err)
+	}
+	want := []h.CurationReconciliation{
+		{Curator: "a", Predicted: h.NewAsset(0.5, h.HIVE), Actual: h.NewAsset(0.562, h.HIVE), Diff: h.NewAsset(0.062, h.HIVE)},
+		{Curator: "b", Predicted: h.NewAsset(0.6, h.HIVE), Actual: h.NewAsset(0, h.HIVE), Diff: h.NewAsset(-0.6, h.HIVE)},
+		{Curator: "c", Predicted: h.NewAsset(0, h.HIVE), Actual: h.NewAsset(1.125, h.HIVE), Diff: h.NewAsset(1.125, h.HIVE)},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ReconcileCurationRewards() = %v, want %v", got, want)
+	}
+
+	if _, err := h.ReconcileCurationRewards([]h.CurationReward{{Voter: "a", Reward: h.NewAsset(1, h.HBD)}}, nil, props.VestingPrice()); err == nil {
+		t.Errorf("ReconcileCurationRewards() should reject predictions not in HIVE")
+	}
+}
@@ -0,0 +1,154 @@
+package gohive
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	h "github.com/nathansenn/go-hive"
+	"github.com/nathansenn/go-hive/hivetest"
+)
+
+type podping struct {
+	Version string   `json:"version"`
+	IRIs    []string `json:"iris"`
+}
+
+func TestCustomJSONOperation_Payload(t *testing.T) {
+	h.RegisterCustomJSON("pp_podcast_update", &podping{})
+	defer h.RegisterCustomJSON("pp_podcast_update", nil)
+
+	tests := []struct {
+		name    string
+		op      h.CustomJSONOperation
+		want    interface{}
+		wantErr bool
+	}{
+		{
+			name: "Follow",
+			op:   h.FollowAccount("jrswab", "hiveio"),
+			want: &h.FollowPayload{Action: "follow", Follow: &h.FollowAction{Follower: "jrswab", Following: "hiveio", What: []string{"blog"}}},
+		},
+		{
+			name: "Legacy follow object",
+			op:   h.CustomJSONOperation{ID: "follow", JSON: `{"follower":"jrswab","following":"hiveio","what":[]}`},
+			want: &h.FollowPayload{Action: "follow", Follow: &h.FollowAction{Follower: "jrswab", Following: "hiveio", What: []string{}}},
+		},
+		{
+			name: "Reblog",
+			op:   h.Reblog("jrswab", "hiveio", "announcement"),
+			want: &h.FollowPayload{Action: "reblog", Reblog: &h.ReblogAction{Account: "jrswab", Author: "hiveio", Permlink: "announcement"}},
+		},
+		{
+			name: "Community",
+			op:   h.CommunityMutePost("jrswab", "hive-123456", "spammer", "spam", "off topic"),
+			want: &h.CommunityPayload{Action: "mutePost", CommunityAction: h.CommunityAction{Community: "hive-123456", Account: "spammer", Permlink: "spam", Notes: "off topic"}},
+		},
+		{
+			name: "Registered type",
+			op:   h.CustomJSONOperation{ID: "pp_podcast_update", JSON: `{"version":"1.0","iris":["https://example.com/feed.xml"]}`},
+			want: &podping{Version: "1.0", IRIs: []string{"https://example.com/feed.xml"}},
+		},
+		{
+			name:    "Unknown follow action",
+			op:      h.CustomJSONOperation{ID: "follow", JSON: `["unfollow",{}]`},
+			wantErr: true,
+		},
+		{
+			name:    "Unregistered id",
+			op:      h.CustomJSONOperation{ID: "sm_claim_reward", JSON: `{}`},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.op.Payload()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CustomJSONOperation.Payload() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CustomJSONOperation.Payload() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+
+	_, err := h.CustomJSONOperation{ID: "sm_claim_reward", JSON: `{}`}.Payload()
+	if !errors.Is(err, h.ErrUnregisteredCustomJSON) {
+		t.Errorf("CustomJSONOperation.Payload() error = %v, want ErrUnregisteredCustomJSON", err)
+	}
+}
+
+func TestDecodeAccountHistory(t *testing.T) {
+	s := hivetest.NewServer()
+	defer s.Close()
+	s.AddAccount(h.AccountData{Name: "jrswab"})
+	s.AddHistory("jrswab", "vote", map[string]interface{}{"voter": "jrswab", "author": "hiveio", "permlink": "announcement", "weight": 10000})
+	s.AddHistory("jrswab", "custom_json", map[string]interface{}{
+		"required_auths":         []string{},
+		"required_posting_auths": []string{"jrswab"},
+		"id":                     "follow",
+		"json":                   `["follow",{"follower":"jrswab","following":"hiveio","what":["blog"]}]`,
+	})
+	s.AddHistory("jrswab", "curation_reward", map[string]interface{}{
+		"curator":                "jrswab",
+		"reward":                 "1.000000 VESTS",
+		"comment_author":         "hiveio",
+		"comment_permlink":       "announcement",
+		"payout_must_be_claimed": true,
+	})
+	s.AddHistory("jrswab", "hardfork", map[string]interface{}{"hardfork_id": 25})
+	s.AddHistory("jrswab", "comment_options", h.CommentOptionsOperation{
+		Author: "jrswab", Permlink: "post", MaxAcceptedPayout: asset("1000000.000 HBD"), PercentHbd: 10000,
+		AllowVotes: true, AllowCurationRewards: true, Beneficiaries: []h.Beneficiary{{Account: "hiveio", Weight: 500}},
+	})
+
+	wantReward := h.CurationRewardOperation{
+		Curator:             "jrswab",
+		Reward:              asset("1.000000 VESTS"),
+		CommentAuthor:       "hiveio",
+		CommentPermlink:     "announcement",
+		PayoutMustBeClaimed: true,
+	}
+	wantPayload := &h.FollowPayload{Action: "follow", Follow: &h.FollowAction{Follower: "jrswab", Following: "hiveio", What: []string{"blog"}}}
+
+	for _, api := range []h.APIMode{h.CondenserAPI, h.AppbaseAPI} {
+		c := h.NewClient(s.URL)
+		c.API = api
+
+		history, err := c.GetAccountHistory("jrswab", -1, 10)
+		if err != nil {
+			t.Fatalf("Chain.GetAccountHistory() error = %v", err)
+		}
+		got, err := h.DecodeAccountHistory(history)
+		if err != nil {
+			t.Fatalf("DecodeAccountHistory() error = %v", err)
+		}
+		if len(got) != 5 {
+			t.Fatalf("DecodeAccountHistory() returned %d entries, want 5", len(got))
+		}
+
+		if vote, ok := got[0].Op.(*h.VoteOperation); !ok || vote.Weight != 10000 || got[0].Index != 0 {
+			t.Errorf("DecodeAccountHistory() entry 0 = %+v", got[0])
+		}
+		if got[1].OpType != h.CustomJSONOp || !reflect.DeepEqual(got[1].Payload, wantPayload) {
+			t.Errorf("DecodeAccountHistory() entry 1 payload = %#v, want %#v", got[1].Payload, wantPayload)
+		}
+		if reward, ok := got[2].Virtual.(*h.CurationRewardOperation); !ok || got[2].Op != nil || *reward != wantReward {
+			t.Errorf("DecodeAccountHistory() entry 2 = %+v, want %+v", got[2].Virtual, wantReward)
+		}
+		if got[3].OpType != "hardfork" || got[3].Op != nil || got[3].Virtual != nil || len(got[3].RawOp) == 0 {
+			t.Errorf("DecodeAccountHistory() entry 3 = %+v", got[3])
+		}
+		if options, ok := got[4].Op.(*h.CommentOptionsOperation); !ok || !reflect.DeepEqual(options.Beneficiaries, []h.Beneficiary{{Account: "hiveio", Weight: 500}}) {
+			t.Errorf("DecodeAccountHistory() entry 4 = %+v, want a beneficiary", got[4].Op)
+		}
+		if rewards := h.HistoryCurationRewards(got); !reflect.DeepEqual(rewards, []h.CurationRewardOperation{wantReward}) {
+			t.Errorf("HistoryCurationRewards() = %v, want [%v]", rewards, wantReward)
+		}
+	}
+
+	if _, err := h.DecodeAccountHistory([][]interface{}{{1}}); err == nil {
+		t.Errorf("DecodeAccountHistory() error = nil for an invalid entry")
+	}
+}
@@ -0,0 +1,229 @@
+package gohive
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	h "github.com/nathansenn/go-hive"
+	"github.com/nathansenn/go-hive/mocks"
+	"github.com/stretchr/testify/mock"
+	rpc "github.com/ybbus/jsonrpc"
+)
+
+func TestChain_GetVestingDelegations(t *testing.T) {
+	mockCall := new(mocks.Caller)
+	output := &rpc.RPCResponse{
+		JSONRPC: "2.0",
+		Result: []interface{}{
+			map[string]interface{}{
+				"id":                  1,
+				"delegator":           "jrswab",
+				"delegatee":           "hiveio",
+				"vesting_shares":      "1000.000000 VESTS",
+				"min_delegation_time": "2020-03-20T14:00:00",
+			},
+		},
+		ID: 0,
+	}
+	output2 := &rpc.RPCResponse{
+		JSONRPC: "2.0",
+		Error:   &rpc.RPCError{Code: 500, Message: "some error"},
+		ID:      0,
+	}
+	mockCall.On("CallRaw", mock.Anything).Return(output, nil).Once()
+	mockCall.On("CallRaw", mock.Anything).Return(nil, fmt.Errorf("fake error message")).Once()
+	mockCall.On("CallRaw", mock.Anything).Return(output2, nil).Once()
+
+	tests := []struct {
+		name    string
+		limit   int
+		want    []h.VestingDelegation
+		wantErr bool
+	}{
+		{
+			name:  "Get delegations",
+			limit: 10,
+			want: []h.VestingDelegation{{
+				ID:                1,
+				Delegator:         "jrswab",
+				Delegatee:         "hiveio",
+				VestingShares:     h.Asset{Amount: 1000000000, Precision: 6, Symbol: h.VESTS},
+				MinDelegationTime: h.Time{Time: time.Date(2020, 3, 20, 14, 0, 0, 0, time.UTC)},
+			}},
+			wantErr: false,
+		},
+		{
+			name:    "Get call error message",
+			limit:   10,
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Get responce error message",
+			limit:   10,
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Limit too large",
+			limit:   1001,
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &h.Client{
+				URL:    "https://api.hive.blog",
+				Client: mockCall,
+			}
+			got, err := c.GetVestingDelegations("jrswab", "", tt.limit)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Chain.GetVestingDelegations() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Chain.GetVestingDelegations() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChain_GetAllVestingDelegations(t *testing.T) {
+	page := make([]interface{}, 0, 1000)
+	for i := 0; i < 1000; i++ {
+		page = append(page, map[string]interface{}{
+			"id":             i,
+			"delegator":      "jrswab",
+			"delegatee":      fmt.Sprintf("user%04d", i),
+			"vesting_shares": "1.000000 VESTS",
+		})
+	}
+	last := page[999]
+
+	mockCall := new(mocks.Caller)
+	mockCall.On("CallRaw", mock.Anything).Return(&rpc.RPCResponse{JSONRPC: "2.0", Result: page}, nil).Once()
+	mockCall.On("CallRaw", mock.Anything).Return(&rpc.RPCResponse{
+		JSONRPC: "2.0",
+		Result: []interface{}{last, map[string]interface{}{
+			"id":             1000,
+			"delegator":      "jrswab",
+			"delegatee":      "user1000",
+			"vesting_shares": "1.000000 VESTS",
+		}},
+	}, nil).Once()
+
+	c := &h.Client{
+		URL:    "https://api.hive.blog",
+		Client: mockCall,
+	}
+	got, err := c.GetAllVestingDelegations("jrswab")
+	if err != nil {
+		t.Fatalf("Chain.GetAllVestingDelegations() error = %v", err)
+	}
+	if len(got) != 1001 {
+		t.Fatalf("Chain.GetAllVestingDelegations() returned %d delegations, want 1001", len(got))
+	}
+	if got[1000].Delegatee != "user1000" {
+		t.Errorf("Chain.GetAllVestingDelegations() last delegatee = %s, want user1000", got[1000].Delegatee)
+	}
+}
+
+func TestChain_FindVestingDelegations(t *testing.T) {
+	mockCall := new(mocks.Caller)
+	output := &rpc.RPCResponse{
+		JSONRPC: "2.0",
+		Result: map[string]interface{}{
+			"delegations": []interface{}{
+				map[string]interface{}{
+					"id":        1,
+					"delegator": "jrswab",
+					"delegatee": "hiveio",
+					"vesting_shares": map[string]interface{}{
+						"amount": "1000000000", "precision": 6, "nai": "@@000000037",
+					},
+					"min_delegation_time": "2020-03-20T14:00:00",
+				},
+			},
+		},
+		ID: 0,
+	}
+	mockCall.On("CallRaw", mock.Anything).Return(output, nil).Once()
+	mockCall.On("CallRaw", mock.Anything).Return(nil, fmt.Errorf("fake error message")).Once()
+
+	tests := []struct {
+		name    string
+		want    []h.VestingDelegation
+		wantErr bool
+	}{
+		{
+			name: "Decode appbase delegations",
+			want: []h.VestingDelegation{{
+				ID:                1,
+				Delegator:         "jrswab",
+				Delegatee:         "hiveio",
+				VestingShares:     h.Asset{Amount: 1000000000, Precision: 6, Symbol: h.VESTS},
+				MinDelegationTime: h.Time{Time: time.Date(2020, 3, 20, 14, 0, 0, 0, time.UTC)},
+			}},
+			wantErr: false,
+		},
+		{
+			name:    "Get call error message",
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &h.Client{
+				URL:    "https://api.hive.blog",
+				Client: mockCall,
+			}
+			got, err := c.FindVestingDelegations("jrswab")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Chain.FindVestingDelegations() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Chain.FindVestingDelegations() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChain_GetExpiringVestingDelegations(t *testing.T) {
+	mockCall := new(mocks.Caller)
+	output := &rpc.RPCResponse{
+		JSONRPC: "2.0",
+		Result: []interface{}{
+			map[string]interface{}{
+				"id":             7,
+				"delegator":      "jrswab",
+				"vesting_shares": "500.000000 VESTS",
+				"expiration":     "2020-03-25T14:00:00",
+			},
+		},
+		ID: 0,
+	}
+	mockCall.On("CallRaw", mock.Anything).Return(output, nil).Once()
+
+	c := &h.Client{
+		URL:    "https://api.hive.blog",
+		Client: mockCall,
+	}
+	got, err := c.GetExpiringVestingDelegations("jrswab", time.Unix(0, 0), 10)
+	if err != nil {
+		t.Fatalf("Chain.GetExpiringVestingDelegations() error = %v", err)
+	}
+	want := []h.ExpiringVestingDelegation{{
+		ID:            7,
+		Delegator:     "jrswab",
+		VestingShares: h.Asset{Amount: 500000000, Precision: 6, Symbol: h.VESTS},
+		Expiration:    h.Time{Time: time.Date(2020, 3, 25, 14, 0, 0, 0, time.UTC)},
+	}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Chain.GetExpiringVestingDelegations() = %v, want %v", got, want)
+	}
+}
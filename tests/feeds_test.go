@@ -0,0 +1,123 @@
+package gohive
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	h "github.com/nathansenn/go-hive"
+	"github.com/nathansenn/go-hive/mocks"
+	"github.com/stretchr/testify/mock"
+	rpc "github.com/ybbus/jsonrpc"
+)
+
+func bridgePost(author, permlink string) map[string]interface{} {
+	return map[string]interface{}{"author": author, "permlink": permlink, "community": "hive-123456"}
+}
+
+func TestChain_GetRankedPosts(t *testing.T) {
+	mockCall := new(mocks.Caller)
+	output := &rpc.RPCResponse{
+		JSONRPC: "2.0",
+		Result:  []interface{}{bridgePost("jrswab", "go-hive")},
+		ID:      0,
+	}
+	output2 := &rpc.RPCResponse{
+		JSONRPC: "2.0",
+		Error:   &rpc.RPCError{Code: 500, Message: "some error"},
+		ID:      0,
+	}
+	mockCall.On("CallRaw", mock.Anything).Return(output, nil).Once()
+	mockCall.On("CallRaw", mock.Anything).Return(nil, fmt.Errorf("fake error message")).Once()
+	mockCall.On("CallRaw", mock.Anything).Return(output2, nil).Once()
+
+	tests := []struct {
+		name    string
+		limit   int
+		want    []h.Post
+		wantErr bool
+	}{
+		{
+			name:    "Get posts",
+			limit:   20,
+			want:    []h.Post{{Author: "jrswab", Permlink: "go-hive", Community: "hive-123456"}},
+			wantErr: false,
+		},
+		{
+			name:    "Get call error message",
+			limit:   20,
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Get responce error message",
+			limit:   20,
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Limit too large",
+			limit:   101,
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &h.Client{
+				URL:    "https://api.hive.blog",
+				Client: mockCall,
+			}
+			got, err := c.GetRankedPosts(h.RankedTrending, "hive-123456", "", h.PostCursor{}, tt.limit)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Chain.GetRankedPosts() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Chain.GetRankedPosts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChain_IterateAccountPosts(t *testing.T) {
+	startsAfter := func(permlink string) interface{} {
+		return mock.MatchedBy(func(req *rpc.RPCRequest) bool {
+			b, _ := json.Marshal(req.Params)
+			if permlink == "" {
+				return !strings.Contains(string(b), "start_permlink")
+			}
+			return strings.Contains(string(b), `"start_permlink":"`+permlink+`"`)
+		})
+	}
+
+	mockCall := new(mocks.Caller)
+	mockCall.On("CallRaw", startsAfter("")).Return(&rpc.RPCResponse{
+		JSONRPC: "2.0",
+		Result:  []interface{}{bridgePost("jrswab", "one"), bridgePost("jrswab", "two")},
+	}, nil).Once()
+	mockCall.On("CallRaw", startsAfter("two")).Return(&rpc.RPCResponse{
+		JSONRPC: "2.0",
+		Result:  []interface{}{bridgePost("jrswab", "three")},
+	}, nil).Once()
+
+	c := &h.Client{
+		URL:    "https://api.hive.blog",
+		Client: mockCall,
+	}
+	it := c.IterateAccountPosts(h.AccountBlog, "jrswab", "", 2)
+	got := []string{}
+	for it.Next() {
+		got = append(got, it.Post().Permlink)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("PostIterator.Err() = %v", err)
+	}
+	want := []string{"one", "two", "three"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("PostIterator walked %v, want %v", got, want)
+	}
+	mockCall.AssertExpectations(t)
+}
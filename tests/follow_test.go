@@ -0,0 +1,236 @@
+package gohive
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+
+	h "github.com/nathansenn/go-hive"
+	"github.com/nathansenn/go-hive/mocks"
+	"github.com/stretchr/testify/mock"
+	rpc "github.com/ybbus/jsonrpc"
+)
+
+func follower(name string) map[string]interface{} {
+	return map[string]interface{}{"follower": name, "following": "jrswab", "what": []interface{}{"blog"}}
+}
+
+func TestChain_GetFollowers(t *testing.T) {
+	mockCall := new(mocks.Caller)
+	output := &rpc.RPCResponse{
+		JSONRPC: "2.0",
+		Result:  []interface{}{follower("hiveio")},
+		ID:      0,
+	}
+	output2 := &rpc.RPCResponse{
+		JSONRPC: "2.0",
+		Error:   &rpc.RPCError{Code: 500, Message: "some error"},
+		ID:      0,
+	}
+	mockCall.On("CallRaw", mock.Anything).Return(output, nil).Once()
+	mockCall.On("CallRaw", mock.Anything).Return(nil, fmt.Errorf("fake error message")).Once()
+	mockCall.On("CallRaw", mock.Anything).Return(output2, nil).Once()
+
+	tests := []struct {
+		name    string
+		limit   int
+		want    []h.Follow
+		wantErr bool
+	}{
+		{
+			name:    "Get followers",
+			limit:   10,
+			want:    []h.Follow{{Follower: "hiveio", Following: "jrswab", What: []string{"blog"}}},
+			wantErr: false,
+		},
+		{
+			name:    "Get call error message",
+			limit:   10,
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Get responce error message",
+			limit:   10,
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Limit too large",
+			limit:   1001,
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &h.Client{
+				URL:    "https://api.hive.blog",
+				Client: mockCall,
+			}
+			got, err := c.GetFollowers("jrswab", "", h.FollowBlog, tt.limit)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Chain.GetFollowers() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Chain.GetFollowers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChain_IterateFollowers(t *testing.T) {
+	mockCall := new(mocks.Caller)
+	mockCall.On("CallRaw", mock.Anything).Return(&rpc.RPCResponse{
+		JSONRPC: "2.0",
+		Result:  []interface{}{follower("alice"), follower("bob"), follower("carol")},
+	}, nil).Once()
+	mockCall.On("CallRaw", mock.Anything).Return(&rpc.RPCResponse{
+		JSONRPC: "2.0",
+		Result:  []interface{}{follower("carol"), follower("dave")},
+	}, nil).Once()
+
+	c := &h.Client{
+		URL:    "https://api.hive.blog",
+		Client: mockCall,
+	}
+	it := c.IterateFollowers("jrswab", h.FollowBlog, 3)
+	got := []string{}
+	for it.Next() {
+		got = append(got, it.Follow().Follower)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("FollowIterator.Err() = %v", err)
+	}
+	want := []string{"alice", "bob", "carol", "dave"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FollowIterator walked %v, want %v", got, want)
+	}
+	mockCall.AssertExpectations(t)
+}
+
+func TestChain_GetRelationship(t *testing.T) {
+	mockCall := new(mocks.Caller)
+	output := &rpc.RPCResponse{
+		JSONRPC: "2.0",
+		Result:  map[string]interface{}{"follows": true, "ignores": false, "blacklists": false, "follows_blacklists": false, "follows_muted": true},
+		ID:      0,
+	}
+	output2 := &rpc.RPCResponse{
+		JSONRPC: "2.0",
+		Error:   &rpc.RPCError{Code: 500, Message: "some error"},
+		ID:      0,
+	}
+	named := mock.MatchedBy(func(req *rpc.RPCRequest) bool {
+		b, _ := json.Marshal(req.Params)
+		return string(b) == `{"account1":"jrswab","account2":"hiveio"}`
+	})
+	mockCall.On("CallRaw", named).Return(output, nil).Once()
+	mockCall.On("CallRaw", named).Return(nil, fmt.Errorf("fake error message")).Once()
+	mockCall.On("CallRaw", named).Return(output2, nil).Once()
+
+	tests := []struct {
+		name    string
+		want    *h.Relationship
+		wantErr bool
+	}{
+		{
+			name:    "Get relationship",
+			want:    &h.Relationship{Follows: true, FollowsMuted: true},
+			wantErr: false,
+		},
+		{
+			name:    "Get call error message",
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Get responce error message",
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &h.Client{
+				URL:    "https://api.hive.blog",
+				Client: mockCall,
+			}
+			got, err := c.GetRelationship("jrswab", "hiveio")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Chain.GetRelationship() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Chain.GetRelationship() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChain_GetFollowCount(t *testing.T) {
+	mockCall := new(mocks.Caller)
+	output := &rpc.RPCResponse{
+		JSONRPC: "2.0",
+		Result:  map[string]interface{}{"account": "jrswab", "follower_count": 120, "following_count": 80},
+		ID:      0,
+	}
+	mockCall.On("CallRaw", mock.Anything).Return(output, nil).Once()
+
+	c := &h.Client{
+		URL:    "https://api.hive.blog",
+		Client: mockCall,
+	}
+	got, err := c.GetFollowCount("jrswab")
+	if err != nil {
+		t.Fatalf("Chain.GetFollowCount() error = %v", err)
+	}
+	want := &h.FollowCount{Account: "jrswab", FollowerCount: 120, FollowingCount: 80}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Chain.GetFollowCount() = %v, want %v", got, want)
+	}
+}
+
+func TestFollowOperations(t *testing.T) {
+	tests := []struct {
+		name string
+		op   h.CustomJSONOperation
+		json string
+	}{
+		{
+			name: "Follow",
+			op:   h.FollowAccount("jrswab", "hiveio"),
+			json: `["follow",{"follower":"jrswab","following":"hiveio","what":["blog"]}]`,
+		},
+		{
+			name: "Unfollow",
+			op:   h.UnfollowAccount("jrswab", "hiveio"),
+			json: `["follow",{"follower":"jrswab","following":"hiveio","what":[]}]`,
+		},
+		{
+			name: "Mute",
+			op:   h.MuteAccount("jrswab", "hiveio"),
+			json: `["follow",{"follower":"jrswab","following":"hiveio","what":["ignore"]}]`,
+		},
+		{
+			name: "Reblog",
+			op:   h.Reblog("jrswab", "hiveio", "announcement"),
+			json: `["reblog",{"account":"jrswab","author":"hiveio","permlink":"announcement"}]`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.op.ID != "follow" {
+				t.Errorf("operation id = %s, want follow", tt.op.ID)
+			}
+			if !reflect.DeepEqual(tt.op.RequiredPostingAuths, []string{"jrswab"}) {
+				t.Errorf("operation posting auths = %v, want [jrswab]", tt.op.RequiredPostingAuths)
+			}
+			if tt.op.JSON != tt.json {
+				t.Errorf("operation json = %s, want %s", tt.op.JSON, tt.json)
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package gohive
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	h "github.com/nathansenn/go-hive"
+	"github.com/nathansenn/go-hive/hivetest"
+	rpc "github.com/ybbus/jsonrpc"
+)
+
+func TestHivetest_Accounts(t *testing.T) {
+	s := hivetest.NewServer()
+	defer s.Close()
+	s.AddAccount(h.AccountData{Name: "jrswab", Balance: "1.000 HIVE", Reputation: "1111"})
+	s.AddAccount(h.AccountData{Name: "hiveio", Balance: "2.000 HIVE"})
+
+	c := h.NewClient(s.URL)
+
+	tests := []struct {
+		name     string
+		accounts []string
+		want     []string
+		wantErr  bool
+	}{
+		{
+			name:     "Get single account",
+			accounts: []string{"jrswab"},
+			want:     []string{"jrswab"},
+		},
+		{
+			name:     "Get two accounts",
+			accounts: []string{"jrswab", "hiveio"},
+			want:     []string{"jrswab", "hiveio"},
+		},
+		{
+			name:     "Unknown accounts are skipped",
+			accounts: []string{"nobody", "hiveio"},
+			want:     []string{"hiveio"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := c.GetAccounts(tt.accounts...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Chain.GetAccounts() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			var names []string
+			for _, acc := range *got {
+				names = append(names, acc.Name)
+			}
+			if !reflect.DeepEqual(names, tt.want) {
+				t.Errorf("Chain.GetAccounts() = %v, want %v", names, tt.want)
+			}
+		})
+	}
+
+	count, err := c.GetAccountCount()
+	if err != nil || count != 2 {
+		t.Errorf("Chain.GetAccountCount() = %v, %v, want 2", count, err)
+	}
+}
+
+func TestHivetest_Errors(t *testing.T) {
+	s := hivetest.NewServer()
+	defer s.Close()
+	c := h.NewClient(s.URL)
+
+	if _, err := c.GetAccountBandwidth("jrswab"); err == nil {
+		t.Errorf("Chain.GetAccountBandwidth() want method not found error")
+	}
+
+	resp, err := c.Client.CallRaw(rpc.NewRequest("get_account_history", "jrswab", -1, 1001))
+	if err != nil {
+		t.Fatalf("CallRaw() error = %v", err)
+	}
+	if resp.Error == nil || resp.Error.Code != hivetest.CodeAssertion {
+		t.Errorf("get_account_history error = %v, want assertion", resp.Error)
+	}
+	resp, err = c.Client.CallRaw(rpc.NewRequest("get_dynamic_global_properties", []interface{}(nil)))
+	if err != nil {
+		t.Fatalf("CallRaw() error = %v", err)
+	}
+	if resp.Error == nil || resp.Error.Code != hivetest.CodeInvalidParams {
+		t.Errorf("get_dynamic_global_properties with null params error = %v, want invalid params", resp.Error)
+	}
+}
+
+func TestHivetest_Broadcast(t *testing.T) {
+	s := hivetest.NewServer()
+	defer s.Close()
+	s.AddAccount(h.AccountData{Name: "jrswab"})
+	s.AddAccount(h.AccountData{Name: "hiveio"})
+	s.AddHistory("jrswab", "account_create", map[string]interface{}{"new_account_name": "jrswab"})
+
+	c := h.NewClient(s.URL)
+	tx := map[string]interface{}{
+		"ref_block_num":    0,
+		"ref_block_prefix": 0,
+		"expiration":       time.Now().UTC().Add(time.Minute).Format(h.TimeLayout),
+		"operations": []interface{}{
+			[]interface{}{"transfer", map[string]interface{}{
+				"from": "jrswab", "to": "hiveio", "amount": "1.000 HIVE", "memo": "",
+			}},
+		},
+		"extensions": []interface{}{},
+		"signatures": []string{},
+	}
+
+	resp, err := c.Client.CallRaw(rpc.NewRequest("condenser_api.broadcast_transaction", []interface{}{tx}))
+	if err != nil || resp.Error != nil {
+		t.Fatalf("broadcast_transaction = %v, %v", resp, err)
+	}
+	if got := len(s.Pending()); got != 1 {
+		t.Errorf("Server.Pending() = %v transactions, want 1", got)
+	}
+
+	b := s.ProduceBlock()
+	if len(b.Transactions) != 1 || len(s.Pending()) != 0 {
+		t.Errorf("Server.ProduceBlock() = %v transactions, want 1", len(b.Transactions))
+	}
+
+	hist, err := c.GetAccountHistory("hiveio", -1, 10)
+	if err != nil {
+		t.Fatalf("Chain.GetAccountHistory() error = %v", err)
+	}
+	if got := len(hist.([][]interface{})); got != 1 {
+		t.Errorf("Chain.GetAccountHistory() = %v entries, want 1", got)
+	}
+
+	hist, err = c.GetAccountHistory("jrswab", -1, 10)
+	if err != nil {
+		t.Fatalf("Chain.GetAccountHistory() error = %v", err)
+	}
+	if got := len(hist.([][]interface{})); got != 2 {
+		t.Errorf("Chain.GetAccountHistory() = %v entries, want 2", got)
+	}
+
+	for i := 0; i < 4; i++ {
+		s.AddHistory("jrswab", "account_witness_vote", map[string]interface{}{"account": "jrswab", "witness": "hiveio", "approve": true})
+	}
+	tests := []struct {
+		start, limit int
+		want         []string
+	}{
+		{start: -1, limit: 3, want: []string{"3", "4", "5"}},
+		{start: 3, limit: 2, want: []string{"2", "3"}},
+		{start: 1, limit: 10, want: []string{"0", "1"}},
+	}
+	for _, tt := range tests {
+		hist, err = c.GetAccountHistory("jrswab", tt.start, tt.limit)
+		if err != nil {
+			t.Fatalf("Chain.GetAccountHistory() error = %v", err)
+		}
+		var got []string
+		for _, entry := range hist.([][]interface{}) {
+			got = append(got, fmt.Sprint(entry[0]))
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Chain.GetAccountHistory(%v, %v) = %v, want %v", tt.start, tt.limit, got, tt.want)
+		}
+	}
+
+	resp, err = c.Client.CallRaw(rpc.NewRequest("get_block", 1))
+	if err != nil || resp.Error != nil {
+		t.Fatalf("get_block = %v, %v", resp, err)
+	}
+	var block hivetest.Block
+	if err := resp.GetObject(&block); err != nil || block.BlockID != b.BlockID {
+		t.Errorf("get_block = %v, want block %v", block.BlockID, b.BlockID)
+	}
+}
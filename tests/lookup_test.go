@@ -0,0 +1,156 @@
+package gohive
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+
+	h "github.com/nathansenn/go-hive"
+	"github.com/nathansenn/go-hive/hivetest"
+	"github.com/nathansenn/go-hive/mocks"
+	"github.com/stretchr/testify/mock"
+	rpc "github.com/ybbus/jsonrpc"
+)
+
+func TestChain_LookupAccounts(t *testing.T) {
+	mockCall := new(mocks.Caller)
+	output := &rpc.RPCResponse{
+		JSONRPC: "2.0",
+		Result:  []interface{}{"jrswab", "jrswab-test", "jrt"},
+		ID:      0,
+	}
+	output2 := &rpc.RPCResponse{
+		JSONRPC: "2.0",
+		Error:   &rpc.RPCError{Code: 500, Message: "some error"},
+		ID:      0,
+	}
+	mockCall.On("CallRaw", mock.Anything).Return(output, nil).Once()
+	mockCall.On("CallRaw", mock.Anything).Return(nil, fmt.Errorf("fake error message")).Once()
+	mockCall.On("CallRaw", mock.Anything).Return(output2, nil).Once()
+
+	type args struct {
+		prefix string
+		limit  int
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:    "Only names with the prefix",
+			args:    args{prefix: "jrswab", limit: 3},
+			want:    []string{"jrswab", "jrswab-test"},
+			wantErr: false,
+		},
+		{
+			name:    "Get call error message",
+			args:    args{prefix: "jrswab", limit: 3},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Get responce error message",
+			args:    args{prefix: "jrswab", limit: 3},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Limit too large",
+			args:    args{prefix: "jrswab", limit: 1001},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &h.Client{
+				URL:    "https://api.hive.blog",
+				Client: mockCall,
+			}
+			got, err := c.LookupAccounts(tt.args.prefix, tt.args.limit)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Chain.LookupAccounts() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Chain.LookupAccounts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChain_IterateAccounts(t *testing.T) {
+	s := hivetest.NewServer()
+	defer s.Close()
+	s.AddAccount(h.AccountData{Name: "alice", Proxy: "carol"})
+	s.AddAccount(h.AccountData{Name: "bob"})
+	s.AddAccount(h.AccountData{Name: "carol", Proxy: "alice"})
+	s.AddAccount(h.AccountData{Name: "dave"})
+	s.AddAccount(h.AccountData{Name: "erin", Proxy: "alice"})
+
+	c := h.NewClient(s.URL)
+
+	tests := []struct {
+		name     string
+		order    h.AccountOrder
+		pageSize int
+		want     []string
+	}{
+		{
+			name:     "By name in pages of two",
+			order:    h.AccountsByName,
+			pageSize: 2,
+			want:     []string{"alice", "bob", "carol", "dave", "erin"},
+		},
+		{
+			name:     "By name in one page",
+			order:    h.AccountsByName,
+			pageSize: 10,
+			want:     []string{"alice", "bob", "carol", "dave", "erin"},
+		},
+		{
+			name:     "By proxy",
+			order:    h.AccountsByProxy,
+			pageSize: 2,
+			want:     []string{"bob", "dave", "carol", "erin", "alice"},
+		},
+		{
+			name:     "Page size above the node limit",
+			order:    h.AccountsByName,
+			pageSize: 5000,
+			want:     []string{"alice", "bob", "carol", "dave", "erin"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			it := c.IterateAccounts(tt.order, tt.pageSize)
+			for it.Next() {
+				got = append(got, it.Account().Name)
+			}
+			if err := it.Err(); err != nil {
+				t.Errorf("AccountIterator.Err() = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("AccountIterator = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	names, err := c.LookupAccounts("c", 10)
+	if err != nil || !reflect.DeepEqual(names, []string{"carol"}) {
+		t.Errorf("Chain.LookupAccounts() = %v, %v, want [carol]", names, err)
+	}
+
+	s.Handle("lookup_accounts", func(json.RawMessage) (interface{}, error) {
+		return nil, fmt.Errorf("condenser_api is disabled")
+	})
+	c.API = h.AppbaseAPI
+	names, err = c.LookupAccounts("c", 10)
+	if err != nil || !reflect.DeepEqual(names, []string{"carol"}) {
+		t.Errorf("Chain.LookupAccounts() with AppbaseAPI = %v, %v, want [carol]", names, err)
+	}
+}
@@ -0,0 +1,218 @@
+package gohive
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	h "github.com/nathansenn/go-hive"
+	"github.com/nathansenn/go-hive/mocks"
+	"github.com/stretchr/testify/mock"
+	rpc "github.com/ybbus/jsonrpc"
+)
+
+func TestChain_GetOrderBook(t *testing.T) {
+	mockCall := new(mocks.Caller)
+	output := &rpc.RPCResponse{
+		JSONRPC: "2.0",
+		Result: map[string]interface{}{
+			"bids": []interface{}{
+				map[string]interface{}{
+					"order_price": map[string]interface{}{"base": "1.000 HBD", "quote": "4.000 HIVE"},
+					"real_price":  "0.25000000000000000",
+					"hive":        "4000",
+					"hbd":         1000,
+					"created":     "2020-03-20T14:00:00",
+				},
+			},
+			"asks": []interface{}{},
+		},
+		ID: 0,
+	}
+	output2 := &rpc.RPCResponse{
+		JSONRPC: "2.0",
+		Error:   &rpc.RPCError{Code: 500, Message: "some error"},
+		ID:      0,
+	}
+	mockCall.On("CallRaw", mock.Anything).Return(output, nil).Once()
+	mockCall.On("CallRaw", mock.Anything).Return(nil, fmt.Errorf("fake error message")).Once()
+	mockCall.On("CallRaw", mock.Anything).Return(output2, nil).Once()
+
+	tests := []struct {
+		name    string
+		limit   int
+		want    *h.OrderBook
+		wantErr bool
+	}{
+		{
+			name:  "Get order book",
+			limit: 10,
+			want: &h.OrderBook{
+				Bids: []h.Order{{
+					OrderPrice: h.Price{Base: h.NewAsset(1, h.HBD), Quote: h.NewAsset(4, h.HIVE)},
+					RealPrice:  json.Number("0.25000000000000000"),
+					Hive:       "4000",
+					Hbd:        "1000",
+					Created:    h.Time{Time: time.Date(2020, 3, 20, 14, 0, 0, 0, time.UTC)},
+				}},
+				Asks: []h.Order{},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "Get call error message",
+			limit:   10,
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Get responce error message",
+			limit:   10,
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Limit too large",
+			limit:   501,
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &h.Client{
+				URL:    "https://api.hive.blog",
+				Client: mockCall,
+			}
+			got, err := c.GetOrderBook(tt.limit)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Chain.GetOrderBook() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Chain.GetOrderBook() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChain_GetTicker(t *testing.T) {
+	mockCall := new(mocks.Caller)
+	output := &rpc.RPCResponse{
+		JSONRPC: "2.0",
+		Result: map[string]interface{}{
+			"latest":         "0.25100000000000000",
+			"lowest_ask":     "0.25200000000000000",
+			"highest_bid":    "0.25000000000000000",
+			"percent_change": "-1.2",
+			"hive_volume":    "12000.000 HIVE",
+			"hbd_volume":     "3000.000 HBD",
+		},
+		ID: 0,
+	}
+	mockCall.On("CallRaw", emptyParams).Return(output, nil).Once()
+
+	c := &h.Client{
+		URL:    "https://api.hive.blog",
+		Client: mockCall,
+	}
+	got, err := c.GetTicker()
+	if err != nil {
+		t.Fatalf("Chain.GetTicker() error = %v", err)
+	}
+	want := &h.Ticker{
+		Latest:        "0.25100000000000000",
+		LowestAsk:     "0.25200000000000000",
+		HighestBid:    "0.25000000000000000",
+		PercentChange: "-1.2",
+		HiveVolume:    h.NewAsset(12000, h.HIVE),
+		HbdVolume:     h.NewAsset(3000, h.HBD),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Chain.GetTicker() = %v, want %v", got, want)
+	}
+}
+
+func TestChain_GetMarketHistory(t *testing.T) {
+	mockCall := new(mocks.Caller)
+	output := &rpc.RPCResponse{
+		JSONRPC: "2.0",
+		Result: []interface{}{
+			map[string]interface{}{
+				"id":       1,
+				"open":     "2020-03-20T14:00:00",
+				"seconds":  3600,
+				"hive":     map[string]interface{}{"high": 4000, "low": 3000, "open": 3500, "close": 3900, "volume": "9007199254740993"},
+				"non_hive": map[string]interface{}{"high": 1000, "low": 750, "open": 875, "close": 975, "volume": 25000},
+			},
+		},
+		ID: 0,
+	}
+	mockCall.On("CallRaw", mock.Anything).Return(output, nil).Once()
+
+	c := &h.Client{
+		URL:    "https://api.hive.blog",
+		Client: mockCall,
+	}
+	start := time.Date(2020, 3, 20, 0, 0, 0, 0, time.UTC)
+	got, err := c.GetMarketHistory(3600, start, start.Add(24*time.Hour))
+	if err != nil {
+		t.Fatalf("Chain.GetMarketHistory() error = %v", err)
+	}
+	want := []h.MarketBucket{{
+		ID:      1,
+		Open:    h.Time{Time: time.Date(2020, 3, 20, 14, 0, 0, 0, time.UTC)},
+		Seconds: 3600,
+		Hive:    h.BucketPrices{High: "4000", Low: "3000", Open: "3500", Close: "3900", Volume: "9007199254740993"},
+		NonHive: h.BucketPrices{High: "1000", Low: "750", Open: "875", Close: "975", Volume: "25000"},
+	}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Chain.GetMarketHistory() = %v, want %v", got, want)
+	}
+}
+
+func TestChain_GetOpenOrders(t *testing.T) {
+	mockCall := new(mocks.Caller)
+	output := &rpc.RPCResponse{
+		JSONRPC: "2.0",
+		Result: []interface{}{
+			map[string]interface{}{
+				"id":         5,
+				"created":    "2020-03-20T14:00:00",
+				"expiration": "2020-04-17T14:00:00",
+				"seller":     "jrswab",
+				"orderid":    77,
+				"for_sale":   "4000",
+				"sell_price": map[string]interface{}{"base": "4.000 HIVE", "quote": "1.000 HBD"},
+				"real_price": "0.25000000000000000",
+				"rewarded":   false,
+			},
+		},
+		ID: 0,
+	}
+	mockCall.On("CallRaw", mock.Anything).Return(output, nil).Once()
+
+	c := &h.Client{
+		URL:    "https://api.hive.blog",
+		Client: mockCall,
+	}
+	got, err := c.GetOpenOrders("jrswab")
+	if err != nil {
+		t.Fatalf("Chain.GetOpenOrders() error = %v", err)
+	}
+	want := []h.OpenOrder{{
+		ID:         5,
+		Created:    h.Time{Time: time.Date(2020, 3, 20, 14, 0, 0, 0, time.UTC)},
+		Expiration: h.Time{Time: time.Date(2020, 4, 17, 14, 0, 0, 0, time.UTC)},
+		Seller:     "jrswab",
+		OrderID:    77,
+		ForSale:    "4000",
+		SellPrice:  h.Price{Base: h.NewAsset(4, h.HIVE), Quote: h.NewAsset(1, h.HBD)},
+		RealPrice:  "0.25000000000000000",
+	}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Chain.GetOpenOrders() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,137 @@
+package gohive
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	h "github.com/nathansenn/go-hive"
+	"github.com/nathansenn/go-hive/metrics"
+	"github.com/nathansenn/go-hive/mocks"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/mock"
+	rpc "github.com/ybbus/jsonrpc"
+)
+
+func TestMetrics_Instrument(t *testing.T) {
+	mockCall := new(mocks.Caller)
+	output := &rpc.RPCResponse{
+		JSONRPC: "2.0",
+		Result:  []interface{}{},
+		ID:      0,
+	}
+	output2 := &rpc.RPCResponse{
+		JSONRPC: "2.0",
+		Error:   &rpc.RPCError{Code: -32601, Message: "could not find method"},
+		ID:      0,
+	}
+	mockCall.On("CallRaw", mock.Anything).Return(output, nil).Once()
+	mockCall.On("CallRaw", mock.Anything).Return(output2, nil).Once()
+	mockCall.On("CallRaw", mock.Anything).Return(nil, fmt.Errorf("fake error message")).Once()
+
+	m := metrics.New("hive")
+	c := &h.Client{URL: "https://api.hive.blog", Client: mockCall}
+	metrics.Instrument(c, m)
+
+	c.GetAccounts("jrswab")
+	c.GetAccountBandwidth("jrswab")
+	c.GetAccountCount()
+	m.ObserveRetry("get_account_count", "https://api.hive.blog")
+
+	want := `
+# HELP hive_rpc_errors_total Number of failed JSON-RPC requests by error class.
+# TYPE hive_rpc_errors_total counter
+hive_rpc_errors_total{class="method_not_found",method="get_account_bandwidth",node="https://api.hive.blog"} 1
+hive_rpc_errors_total{class="transport",method="get_account_count",node="https://api.hive.blog"} 1
+# HELP hive_rpc_requests_total Number of JSON-RPC requests sent to Hive nodes.
+# TYPE hive_rpc_requests_total counter
+hive_rpc_requests_total{method="get_account_bandwidth",node="https://api.hive.blog"} 1
+hive_rpc_requests_total{method="get_account_count",node="https://api.hive.blog"} 1
+hive_rpc_requests_total{method="get_accounts",node="https://api.hive.blog"} 1
+# HELP hive_rpc_retries_total Number of retried JSON-RPC requests.
+# TYPE hive_rpc_retries_total counter
+hive_rpc_retries_total{method="get_account_count",node="https://api.hive.blog"} 1
+`
+	err := testutil.CollectAndCompare(m, strings.NewReader(want),
+		"hive_rpc_errors_total", "hive_rpc_requests_total", "hive_rpc_retries_total")
+	if err != nil {
+		t.Errorf("metrics.Instrument() %v", err)
+	}
+	if got := testutil.CollectAndCount(m, "hive_rpc_request_duration_seconds"); got != 3 {
+		t.Errorf("metrics.Instrument() latency series = %v, want 3", got)
+	}
+}
+
+func TestMetrics_InstrumentWebSocket(t *testing.T) {
+	live := newEchoServer(t, 0)
+	defer live.Close()
+	dead := httptest.NewServer(http.NotFoundHandler())
+	deadURL := wsURL(dead)
+	dead.Close()
+
+	ws := h.NewWSCaller(deadURL)
+	ws.Fallbacks = []string{wsURL(live)}
+	defer ws.Close()
+
+	m := metrics.New("hive")
+	c := &h.Client{URL: deadURL, Client: ws}
+	metrics.Instrument(c, m)
+
+	for i := 0; i < 2; i++ {
+		if got, err := c.GetAccountCount(); err != nil || got != 1111 {
+			t.Fatalf("Chain.GetAccountCount() = %v, %v, want 1111", got, err)
+		}
+	}
+
+	want := fmt.Sprintf(`
+# HELP hive_rpc_failovers_total Number of JSON-RPC requests moved to another node.
+# TYPE hive_rpc_failovers_total counter
+hive_rpc_failovers_total{from=%q,method="get_account_count",to=%q} 1
+# HELP hive_rpc_retries_total Number of retried JSON-RPC requests.
+# TYPE hive_rpc_retries_total counter
+hive_rpc_retries_total{method="get_account_count",node=%q} 1
+`, deadURL, wsURL(live), wsURL(live))
+	err := testutil.CollectAndCompare(m, strings.NewReader(want), "hive_rpc_failovers_total", "hive_rpc_retries_total")
+	if err != nil {
+		t.Errorf("metrics.Instrument() %v", err)
+	}
+}
+
+func TestMetrics_ErrorClass(t *testing.T) {
+	tests := []struct {
+		name string
+		resp *rpc.RPCResponse
+		err  error
+		want string
+	}{
+		{
+			name: "Success",
+			resp: &rpc.RPCResponse{JSONRPC: "2.0"},
+			want: "",
+		},
+		{
+			name: "Transport error",
+			err:  fmt.Errorf("fake error message"),
+			want: metrics.ClassTransport,
+		},
+		{
+			name: "Invalid params",
+			resp: &rpc.RPCResponse{Error: &rpc.RPCError{Code: -32602}},
+			want: metrics.ClassInvalidParams,
+		},
+		{
+			name: "Node assertion",
+			resp: &rpc.RPCResponse{Error: &rpc.RPCError{Code: -32000}},
+			want: metrics.ClassServer,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := metrics.ErrorClass(tt.resp, tt.err); got != tt.want {
+				t.Errorf("metrics.ErrorClass() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,210 @@
+package gohive
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	h "github.com/nathansenn/go-hive"
+	"github.com/nathansenn/go-hive/mocks"
+	"github.com/stretchr/testify/mock"
+	rpc "github.com/ybbus/jsonrpc"
+)
+
+func notification(id int64) map[string]interface{} {
+	return map[string]interface{}{
+		"id":    id,
+		"type":  "vote",
+		"score": 50,
+		"date":  "2020-03-20T14:00:00",
+		"msg":   "@hiveio voted on your post",
+		"url":   "@jrswab/go-hive",
+	}
+}
+
+func TestChain_AccountNotifications(t *testing.T) {
+	mockCall := new(mocks.Caller)
+	output := &rpc.RPCResponse{
+		JSONRPC: "2.0",
+		Result:  []interface{}{notification(7)},
+		ID:      0,
+	}
+	output2 := &rpc.RPCResponse{
+		JSONRPC: "2.0",
+		Error:   &rpc.RPCError{Code: 500, Message: "some error"},
+		ID:      0,
+	}
+	mockCall.On("CallRaw", mock.Anything).Return(output, nil).Once()
+	mockCall.On("CallRaw", mock.Anything).Return(nil, fmt.Errorf("fake error message")).Once()
+	mockCall.On("CallRaw", mock.Anything).Return(output2, nil).Once()
+
+	tests := []struct {
+		name    string
+		limit   int
+		want    []h.Notification
+		wantErr bool
+	}{
+		{
+			name:  "Get notifications",
+			limit: 10,
+			want: []h.Notification{{
+				ID:    7,
+				Type:  "vote",
+				Score: 50,
+				Date:  h.Time{Time: time.Date(2020, 3, 20, 14, 0, 0, 0, time.UTC)},
+				Msg:   "@hiveio voted on your post",
+				URL:   "@jrswab/go-hive",
+			}},
+			wantErr: false,
+		},
+		{
+			name:    "Get call error message",
+			limit:   10,
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Get responce error message",
+			limit:   10,
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Limit too large",
+			limit:   101,
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &h.Client{
+				URL:    "https://api.hive.blog",
+				Client: mockCall,
+			}
+			got, err := c.AccountNotifications("jrswab", 0, tt.limit)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Chain.AccountNotifications() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Chain.AccountNotifications() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChain_WatchNotifications(t *testing.T) {
+	interval := h.NotificationPollInterval
+	h.NotificationPollInterval = 10 * time.Millisecond
+	defer func() { h.NotificationPollInterval = interval }()
+
+	mockCall := new(mocks.Caller)
+	mockCall.On("CallRaw", mock.Anything).Return(&rpc.RPCResponse{
+		JSONRPC: "2.0",
+		Result:  []interface{}{notification(2), notification(1)},
+	}, nil).Once()
+	mockCall.On("CallRaw", mock.Anything).Return(nil, fmt.Errorf("fake error message")).Once()
+	mockCall.On("CallRaw", mock.Anything).Return(&rpc.RPCResponse{
+		JSONRPC: "2.0",
+		Result:  []interface{}{notification(4), notification(3), notification(2), notification(1)},
+	}, nil)
+
+	c := &h.Client{
+		URL:    "https://api.hive.blog",
+		Client: mockCall,
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	notes, errs := c.WatchNotifications(ctx, "jrswab")
+
+	got := []int64{}
+	timeout := time.After(2 * time.Second)
+	for len(got) < 2 {
+		select {
+		case n := <-notes:
+			got = append(got, n.ID)
+		case <-timeout:
+			t.Fatalf("WatchNotifications() sent %v before timing out", got)
+		}
+	}
+	if !reflect.DeepEqual(got, []int64{3, 4}) {
+		t.Errorf("WatchNotifications() sent %v, want [3 4]", got)
+	}
+	if err := <-errs; err == nil {
+		t.Errorf("WatchNotifications() should report the failed poll")
+	}
+
+	cancel()
+	for range notes {
+		t.Errorf("WatchNotifications() sent a notification seen before")
+	}
+}
+
+// notificationNode answers bridge.account_notifications from ids, newest first.
+// Every call after the first adds burst new notifications before answering.
+type notificationNode struct {
+	mu    sync.Mutex
+	last  int64
+	burst int64
+	calls int
+}
+
+func (n *notificationNode) CallRaw(req *rpc.RPCRequest) (*rpc.RPCResponse, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.calls++
+	if n.calls == 2 {
+		n.last += n.burst
+	}
+
+	var p struct {
+		LastID int64 `json:"last_id"`
+		Limit  int   `json:"limit"`
+	}
+	b, _ := json.Marshal(req.Params)
+	json.Unmarshal(b, &p)
+
+	page := []interface{}{}
+	id := n.last
+	if p.LastID > 0 {
+		id = p.LastID - 1
+	}
+	for ; id > 0 && len(page) < p.Limit; id-- {
+		page = append(page, notification(id))
+	}
+	return &rpc.RPCResponse{JSONRPC: "2.0", Result: page}, nil
+}
+
+func TestChain_WatchNotificationsBurst(t *testing.T) {
+	interval := h.NotificationPollInterval
+	h.NotificationPollInterval = 10 * time.Millisecond
+	defer func() { h.NotificationPollInterval = interval }()
+
+	node := &notificationNode{last: 5, burst: 250}
+	c := &h.Client{URL: "https://api.hive.blog", Client: node}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	notes, _ := c.WatchNotifications(ctx, "jrswab")
+
+	want := []int64{}
+	for id := int64(6); id <= 255; id++ {
+		want = append(want, id)
+	}
+	got := []int64{}
+	timeout := time.After(2 * time.Second)
+	for len(got) < len(want) {
+		select {
+		case n := <-notes:
+			got = append(got, n.ID)
+		case <-timeout:
+			t.Fatalf("WatchNotifications() sent %v notifications before timing out, want %v", len(got), len(want))
+		}
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("WatchNotifications() sent %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,584 @@
+package gohive
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	h "github.com/nathansenn/go-hive"
+)
+
+func asset(s string) h.Asset {
+	a, err := h.ParseAsset(s)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+func TestValidateAccountName(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{name: "jrswab", wantErr: false},
+		{name: "hive-123456", wantErr: false},
+		{name: "foo.bar1", wantErr: false},
+		{name: "ab", wantErr: true},
+		{name: "abcdefghijklmnopq", wantErr: true},
+		{name: "Jrswab", wantErr: true},
+		{name: "1abc", wantErr: true},
+		{name: "abc-", wantErr: true},
+		{name: "foo.ba", wantErr: true},
+		{name: "foo_bar", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := h.ValidateAccountName(tt.name); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateAccountName() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEncodeOperation(t *testing.T) {
+	start := h.Time{Time: time.Date(2020, 4, 17, 14, 0, 0, 0, time.UTC)}
+	end := h.Time{Time: start.Add(24 * time.Hour)}
+	tests := []struct {
+		name    string
+		op      h.Operation
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "Vote",
+			op:   h.VoteOperation{Voter: "foo", Author: "bar", Permlink: "baz", Weight: 10000},
+			want: "0003666f6f036261720362617a1027",
+		},
+		{
+			name: "Transfer",
+			op:   h.TransferOperation{From: "foo", To: "bar", Amount: asset("1.000 HIVE"), Memo: "wedding present"},
+			want: "0203666f6f03626172e80300000000000003535445454d00000f77656464696e672070726573656e74",
+		},
+		{
+			name: "Custom JSON sorts auths",
+			op:   h.CustomJSONOperation{RequiredAuths: []string{}, RequiredPostingAuths: []string{"foo"}, ID: "follow", JSON: "{}"},
+			want: "12000103666f6f06666f6c6c6f7702" + "7b7d",
+		},
+		{
+			name: "Comment options with a beneficiary",
+			op: h.CommentOptionsOperation{
+				Author: "foo", Permlink: "bar", MaxAcceptedPayout: asset("1000.000 HBD"), PercentHbd: 10000,
+				AllowVotes: true, AllowCurationRewards: true, Beneficiaries: []h.Beneficiary{{Account: "baz", Weight: 500}},
+			},
+			want: "1303666f6f0362617240420f0000000000035342440000000010270101010001" + "0362617af401",
+		},
+		{
+			name: "Update proposal votes",
+			op:   h.UpdateProposalVotesOperation{Voter: "foo", ProposalIDs: []int64{2, 1}, Approve: true},
+			want: "2d03666f6f02" + "0100000000000000" + "0200000000000000" + "0100",
+		},
+		{
+			name: "Limit order cancel",
+			op:   h.LimitOrderCancelOperation{Owner: "foo", OrderID: 1},
+			want: "0603666f6f01000000",
+		},
+		{
+			name: "Delete comment",
+			op:   h.DeleteCommentOperation{Author: "foo", Permlink: "bar"},
+			want: "1103666f6f03626172",
+		},
+		{
+			name: "Set withdraw vesting route",
+			op:   h.SetWithdrawVestingRouteOperation{FromAccount: "foo", ToAccount: "bar", Percent: 2500, AutoVest: true},
+			want: "1403666f6f03626172c40901",
+		},
+		{
+			name: "Transfer to savings",
+			op:   h.TransferToSavingsOperation{From: "foo", To: "bar", Amount: asset("1.000 HBD")},
+			want: "2003666f6f03626172e803000000000000035342440000000000",
+		},
+		{
+			name: "Transfer from savings",
+			op:   h.TransferFromSavingsOperation{From: "foo", RequestID: 1, To: "bar", Amount: asset("1.000 HIVE")},
+			want: "2103666f6f0100000003626172e80300000000000003535445454d000000",
+		},
+		{
+			name: "Cancel transfer from savings",
+			op:   h.CancelTransferFromSavingsOperation{From: "foo", RequestID: 1},
+			want: "2203666f6f01000000",
+		},
+		{
+			name: "Feed publish",
+			op:   h.FeedPublishOperation{Publisher: "foo", ExchangeRate: h.Price{Base: asset("0.250 HBD"), Quote: asset("1.000 HIVE")}},
+			want: "0703666f6f" + "fa000000000000000353424400000000" + "e80300000000000003535445454d0000",
+		},
+		{
+			name: "Claim account",
+			op:   h.ClaimAccountOperation{Creator: "foo", Fee: asset("3.000 HIVE")},
+			want: "1603666f6fb80b00000000000003535445454d000000",
+		},
+		{
+			name: "Change recovery account",
+			op:   h.ChangeRecoveryAccountOperation{AccountToRecover: "foo", NewRecoveryAccount: "bar"},
+			want: "1a03666f6f0362617200",
+		},
+		{
+			name: "Decline voting rights",
+			op:   h.DeclineVotingRightsOperation{Account: "foo", Decline: true},
+			want: "2403666f6f01",
+		},
+		{
+			name: "Custom",
+			op:   h.CustomOperation{RequiredAuths: []string{"foo"}, ID: 7, Data: "beef"},
+			want: "0f0103666f6f070002beef",
+		},
+		{
+			name: "Limit order create2",
+			op: h.LimitOrderCreate2Operation{
+				Owner: "foo", OrderID: 7, AmountToSell: asset("1.000 HIVE"),
+				ExchangeRate: h.Price{Base: asset("4.000 HIVE"), Quote: asset("1.000 HBD")}, Expiration: start,
+			},
+			want: "1503666f6f07000000" + "e80300000000000003535445454d0000" + "00" +
+				"a00f00000000000003535445454d0000" + "e8030000000000000353424400000000" + "60b6995e",
+		},
+		{
+			name: "Escrow transfer",
+			op: h.EscrowTransferOperation{
+				From: "foo", To: "bar", HbdAmount: asset("1.000 HBD"), HiveAmount: asset("0.000 HIVE"), EscrowID: 3,
+				Agent: "baz", Fee: asset("0.010 HIVE"), RatificationDeadline: start, EscrowExpiration: end,
+			},
+			want: "1b03666f6f03626172" + "e8030000000000000353424400000000" + "000000000000000003535445454d0000" +
+				"030000000362617a" + "0a0000000000000003535445454d0000" + "00" + "60b6995ee0079b5e",
+		},
+		{
+			name: "Escrow approve",
+			op:   h.EscrowApproveOperation{From: "foo", To: "bar", Agent: "baz", Who: "bar", EscrowID: 3, Approve: true},
+			want: "1f03666f6f036261720362617a036261720300000001",
+		},
+		{
+			name: "Escrow dispute",
+			op:   h.EscrowDisputeOperation{From: "foo", To: "bar", Agent: "baz", Who: "foo", EscrowID: 3},
+			want: "1c03666f6f036261720362617a03666f6f03000000",
+		},
+		{
+			name: "Escrow release",
+			op: h.EscrowReleaseOperation{
+				From: "foo", To: "bar", Agent: "baz", Who: "baz", Receiver: "bar", EscrowID: 3,
+				HbdAmount: asset("1.000 HBD"), HiveAmount: asset("0.000 HIVE"),
+			},
+			want: "1d03666f6f036261720362617a0362617a0362617203000000" +
+				"e8030000000000000353424400000000" + "000000000000000003535445454d0000",
+		},
+		{
+			name: "Create proposal",
+			op: h.CreateProposalOperation{
+				Creator: "foo", Receiver: "bar", StartDate: start, EndDate: end,
+				DailyPay: asset("1.000 HBD"), Subject: "Fund me", Permlink: "fund-me",
+			},
+			want: "2c03666f6f0362617260b6995ee0079b5ee80300000000000003534244000000000746756e64206d650766756e642d6d6500",
+		},
+		{
+			name: "Update proposal",
+			op:   h.UpdateProposalOperation{ProposalID: 5, Creator: "foo", DailyPay: asset("0.500 HBD"), Subject: "Fund me", Permlink: "fund-me"},
+			want: "2f050000000000000003666f6ff40100000000000003534244000000000746756e64206d650766756e642d6d6500",
+		},
+		{
+			name: "Remove proposal sorts ids",
+			op:   h.RemoveProposalOperation{ProposalOwner: "foo", ProposalIDs: []int64{2, 1}},
+			want: "2e03666f6f02" + "0100000000000000" + "0200000000000000" + "00",
+		},
+		{
+			name:    "Invalid weight",
+			op:      h.VoteOperation{Voter: "foo", Author: "bar", Permlink: "baz", Weight: 10001},
+			wantErr: true,
+		},
+		{
+			name:    "Transfer of VESTS",
+			op:      h.TransferOperation{From: "foo", To: "bar", Amount: asset("1.000000 VESTS")},
+			wantErr: true,
+		},
+		{
+			name:    "Unsupported extensions",
+			op:      h.RecurrentTransferOperation{From: "foo", To: "bar", Amount: asset("1.000 HIVE"), Recurrence: 24, Executions: 2, Extensions: h.Extensions{1}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := h.EncodeOperation(tt.op)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("EncodeOperation() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if hex.EncodeToString(got) != tt.want {
+				t.Errorf("EncodeOperation() = %x, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOperationValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		op      h.Operation
+		wantErr bool
+	}{
+		{
+			name: "Valid comment",
+			op:   h.CommentOperation{ParentPermlink: "hive", Author: "foo", Permlink: "bar", Title: "Hello", Body: "World", JSONMetadata: `{"tags":["hive"]}`},
+		},
+		{
+			name:    "Comment with bad metadata",
+			op:      h.CommentOperation{ParentPermlink: "hive", Author: "foo", Permlink: "bar", Body: "World", JSONMetadata: "{"},
+			wantErr: true,
+		},
+		{
+			name:    "Comment without a permlink",
+			op:      h.CommentOperation{ParentPermlink: "hive", Author: "foo", Body: "World"},
+			wantErr: true,
+		},
+		{
+			name:    "Transfer of nothing",
+			op:      h.TransferOperation{From: "foo", To: "bar", Amount: asset("0.000 HBD")},
+			wantErr: true,
+		},
+		{
+			name: "Transfer with the longest memo",
+			op:   h.TransferOperation{From: "foo", To: "bar", Amount: asset("1.000 HIVE"), Memo: strings.Repeat("a", 2047)},
+		},
+		{
+			name:    "Transfer with a 2048 byte memo",
+			op:      h.TransferOperation{From: "foo", To: "bar", Amount: asset("1.000 HIVE"), Memo: strings.Repeat("a", 2048)},
+			wantErr: true,
+		},
+		{
+			name:    "Delegation to yourself",
+			op:      h.DelegateVestingSharesOperation{Delegator: "foo", Delegatee: "foo", VestingShares: asset("1.000000 VESTS")},
+			wantErr: true,
+		},
+		{
+			name:    "Claim of nothing",
+			op:      h.ClaimRewardBalanceOperation{Account: "foo", RewardHive: asset("0.000 HIVE"), RewardHbd: asset("0.000 HBD"), RewardVests: asset("0.000000 VESTS")},
+			wantErr: true,
+		},
+		{
+			name:    "Limit order trading HIVE for HIVE",
+			op:      h.LimitOrderCreateOperation{Owner: "foo", AmountToSell: asset("1.000 HIVE"), MinToReceive: asset("2.000 HIVE")},
+			wantErr: true,
+		},
+		{
+			name:    "Limit order without expiration",
+			op:      h.LimitOrderCreateOperation{Owner: "foo", AmountToSell: asset("1.000 HIVE"), MinToReceive: asset("0.250 HBD")},
+			wantErr: true,
+		},
+		{
+			name: "Limit order from history",
+			op:   h.LimitOrderCreateOperation{Owner: "foo", AmountToSell: asset("1.000 HIVE"), MinToReceive: asset("0.250 HBD"), Expiration: h.Time{Time: time.Date(2020, 4, 17, 14, 0, 0, 0, time.UTC)}},
+		},
+		{
+			name:    "Feed with a HIVE/HIVE price",
+			op:      h.FeedPublishOperation{Publisher: "foo", ExchangeRate: h.Price{Base: asset("1.000 HIVE"), Quote: asset("1.000 HIVE")}},
+			wantErr: true,
+		},
+		{
+			name:    "Claim account paid in HBD",
+			op:      h.ClaimAccountOperation{Creator: "foo", Fee: asset("3.000 HBD")},
+			wantErr: true,
+		},
+		{
+			name:    "Custom without auths",
+			op:      h.CustomOperation{ID: 7, Data: "beef"},
+			wantErr: true,
+		},
+		{
+			name:    "Custom with data that is not hex",
+			op:      h.CustomOperation{RequiredAuths: []string{"foo"}, ID: 7, Data: "xyz"},
+			wantErr: true,
+		},
+		{
+			name: "Limit order create2 selling the quote",
+			op: h.LimitOrderCreate2Operation{
+				Owner: "foo", AmountToSell: asset("1.000 HBD"), ExchangeRate: h.Price{Base: asset("4.000 HIVE"), Quote: asset("1.000 HBD")},
+				Expiration: h.Time{Time: time.Date(2020, 4, 17, 14, 0, 0, 0, time.UTC)},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Limit order create2 worth nothing",
+			op: h.LimitOrderCreate2Operation{
+				Owner: "foo", AmountToSell: asset("0.001 HIVE"), ExchangeRate: h.Price{Base: asset("4.000 HIVE"), Quote: asset("1.000 HBD")},
+				Expiration: h.Time{Time: time.Date(2020, 4, 17, 14, 0, 0, 0, time.UTC)},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Escrow with the receiver as agent",
+			op: h.EscrowTransferOperation{
+				From: "foo", To: "bar", Agent: "bar", HbdAmount: asset("1.000 HBD"), HiveAmount: asset("0.000 HIVE"), Fee: asset("0.000 HIVE"),
+				RatificationDeadline: h.Time{Time: time.Unix(1, 0)}, EscrowExpiration: h.Time{Time: time.Unix(2, 0)},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Escrow of nothing",
+			op: h.EscrowTransferOperation{
+				From: "foo", To: "bar", Agent: "baz", HbdAmount: asset("0.000 HBD"), HiveAmount: asset("0.000 HIVE"), Fee: asset("0.000 HIVE"),
+				RatificationDeadline: h.Time{Time: time.Unix(1, 0)}, EscrowExpiration: h.Time{Time: time.Unix(2, 0)},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Escrow expiring before ratification",
+			op: h.EscrowTransferOperation{
+				From: "foo", To: "bar", Agent: "baz", HbdAmount: asset("1.000 HBD"), HiveAmount: asset("0.000 HIVE"), Fee: asset("0.000 HIVE"),
+				RatificationDeadline: h.Time{Time: time.Unix(2, 0)}, EscrowExpiration: h.Time{Time: time.Unix(1, 0)},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "Escrow approved by the sender",
+			op:      h.EscrowApproveOperation{From: "foo", To: "bar", Agent: "baz", Who: "foo", Approve: true},
+			wantErr: true,
+		},
+		{
+			name:    "Escrow disputed by the agent",
+			op:      h.EscrowDisputeOperation{From: "foo", To: "bar", Agent: "baz", Who: "baz"},
+			wantErr: true,
+		},
+		{
+			name: "Escrow released to the agent",
+			op: h.EscrowReleaseOperation{
+				From: "foo", To: "bar", Agent: "baz", Who: "foo", Receiver: "baz",
+				HbdAmount: asset("1.000 HBD"), HiveAmount: asset("0.000 HIVE"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Proposal ending before it starts",
+			op: h.CreateProposalOperation{
+				Creator: "foo", Receiver: "bar", StartDate: h.Time{Time: time.Unix(2, 0)}, EndDate: h.Time{Time: time.Unix(1, 0)},
+				DailyPay: asset("1.000 HBD"), Subject: "Fund me", Permlink: "fund-me",
+			},
+			wantErr: true,
+		},
+		{
+			name:    "Proposal update with a long subject",
+			op:      h.UpdateProposalOperation{Creator: "foo", DailyPay: asset("1.000 HBD"), Subject: strings.Repeat("a", 81), Permlink: "fund-me"},
+			wantErr: true,
+		},
+		{
+			name:    "Proposal removal of six proposals",
+			op:      h.RemoveProposalOperation{ProposalOwner: "foo", ProposalIDs: []int64{1, 2, 3, 4, 5, 6}},
+			wantErr: true,
+		},
+		{
+			name:    "Unsorted beneficiaries",
+			op:      h.CommentOptionsOperation{Author: "foo", Permlink: "bar", MaxAcceptedPayout: asset("0.000 HBD"), Beneficiaries: []h.Beneficiary{{Account: "bob", Weight: 100}, {Account: "alice", Weight: 100}}},
+			wantErr: true,
+		},
+		{
+			name:    "Beneficiaries over 100%",
+			op:      h.CommentOptionsOperation{Author: "foo", Permlink: "bar", MaxAcceptedPayout: asset("0.000 HBD"), Beneficiaries: []h.Beneficiary{{Account: "alice", Weight: 6000}, {Account: "bob", Weight: 5000}}},
+			wantErr: true,
+		},
+		{
+			name:    "Too many proposal ids",
+			op:      h.UpdateProposalVotesOperation{Voter: "foo", ProposalIDs: []int64{1, 2, 3, 4, 5, 6}},
+			wantErr: true,
+		},
+		{
+			name:    "Recurrence too short",
+			op:      h.RecurrentTransferOperation{From: "foo", To: "bar", Amount: asset("1.000 HIVE"), Recurrence: 12, Executions: 2},
+			wantErr: true,
+		},
+		{
+			name:    "Proxy to yourself",
+			op:      h.AccountWitnessProxyOperation{Account: "foo", Proxy: "foo"},
+			wantErr: true,
+		},
+		{
+			name:    "Custom JSON without auths",
+			op:      h.CustomJSONOperation{ID: "follow", JSON: "{}"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.op.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Operation.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOperationConstructors(t *testing.T) {
+	if _, err := h.NewVote("foo", "bar", "baz", -20000); err == nil {
+		t.Errorf("NewVote() error = nil, want an error")
+	}
+
+	op, err := h.NewCommentOptions("foo", "bar", asset("1000000.000 HBD"), 5000, []h.Beneficiary{{Account: "bob", Weight: 100}, {Account: "alice", Weight: 200}})
+	if err != nil {
+		t.Fatalf("NewCommentOptions() error = %v", err)
+	}
+	want := []h.Beneficiary{{Account: "alice", Weight: 200}, {Account: "bob", Weight: 100}}
+	if !reflect.DeepEqual(op.Beneficiaries, want) {
+		t.Errorf("NewCommentOptions() beneficiaries = %v, want %v", op.Beneficiaries, want)
+	}
+
+	constructed := []struct {
+		name string
+		op   h.Operation
+		err  error
+		want h.Operation
+	}{
+		{name: "NewDeleteComment", want: h.DeleteCommentOperation{Author: "foo", Permlink: "bar"}},
+		{name: "NewSetWithdrawVestingRoute", want: h.SetWithdrawVestingRouteOperation{FromAccount: "foo", ToAccount: "bar", Percent: 2500, AutoVest: true}},
+		{name: "NewLimitOrderCancel", want: h.LimitOrderCancelOperation{Owner: "foo", OrderID: 7}},
+		{name: "NewTransferToSavings", want: h.TransferToSavingsOperation{From: "foo", To: "bar", Amount: asset("1.000 HBD"), Memo: "rainy day"}},
+		{name: "NewTransferFromSavings", want: h.TransferFromSavingsOperation{From: "foo", RequestID: 3, To: "bar", Amount: asset("1.000 HIVE")}},
+		{name: "NewCancelTransferFromSavings", want: h.CancelTransferFromSavingsOperation{From: "foo", RequestID: 3}},
+	}
+	constructed[0].op, constructed[0].err = h.NewDeleteComment("foo", "bar")
+	constructed[1].op, constructed[1].err = h.NewSetWithdrawVestingRoute("foo", "bar", 2500, true)
+	constructed[2].op, constructed[2].err = h.NewLimitOrderCancel("foo", 7)
+	constructed[3].op, constructed[3].err = h.NewTransferToSavings("foo", "bar", asset("1.000 HBD"), "rainy day")
+	constructed[4].op, constructed[4].err = h.NewTransferFromSavings("foo", 3, "bar", asset("1.000 HIVE"), "")
+	constructed[5].op, constructed[5].err = h.NewCancelTransferFromSavings("foo", 3)
+	for _, tt := range constructed {
+		if tt.err != nil || !reflect.DeepEqual(tt.op, tt.want) {
+			t.Errorf("%s() = %v, %v, want %v", tt.name, tt.op, tt.err, tt.want)
+		}
+	}
+
+	if _, err := h.NewSetWithdrawVestingRoute("foo", "bar", 10001, false); err == nil {
+		t.Errorf("NewSetWithdrawVestingRoute() error = nil, want an error")
+	}
+	if _, err := h.NewTransferToSavings("foo", "bar", asset("1.000000 VESTS"), ""); err == nil {
+		t.Errorf("NewTransferToSavings() error = nil, want an error")
+	}
+	if _, err := h.NewDeleteComment("foo", ""); err == nil {
+		t.Errorf("NewDeleteComment() error = nil, want an error")
+	}
+
+	for _, tt := range []struct {
+		name       string
+		expiration time.Duration
+		wantErr    bool
+	}{
+		{name: "Expiring in an hour", expiration: time.Hour, wantErr: false},
+		{name: "Expired", expiration: -time.Hour, wantErr: true},
+		{name: "Expiring after 28 days", expiration: 29 * 24 * time.Hour, wantErr: true},
+	} {
+		_, err := h.NewLimitOrderCreate("foo", 7, asset("1.000 HIVE"), asset("0.250 HBD"), false, h.Time{Time: time.Now().Add(tt.expiration)})
+		if (err != nil) != tt.wantErr {
+			t.Errorf("NewLimitOrderCreate() %s error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+
+	if _, err := h.NewLimitOrderCreate2("foo", 7, asset("1.000 HIVE"), h.Price{Base: asset("4.000 HIVE"), Quote: asset("1.000 HBD")}, false, h.Time{Time: time.Now().Add(-time.Hour)}); err == nil {
+		t.Errorf("NewLimitOrderCreate2() error = nil for an expired order")
+	}
+	escrow, err := h.NewEscrowTransfer("foo", "bar", "baz", 3, asset("1.000 HBD"), asset("0.000 HIVE"), asset("0.010 HIVE"),
+		h.Time{Time: time.Unix(1, 0)}, h.Time{Time: time.Unix(2, 0)}, map[string]string{"terms": "delivery"})
+	if err != nil || escrow.JSONMeta != `{"terms":"delivery"}` {
+		t.Errorf("NewEscrowTransfer() = %v, %v", escrow, err)
+	}
+	custom, err := h.NewCustom([]string{"foo"}, 7, []byte{0xbe, 0xef})
+	if err != nil || custom.Data != "beef" {
+		t.Errorf("NewCustom() = %v, %v", custom, err)
+	}
+
+	update, err := h.NewAccountUpdate2("foo", map[string]interface{}{"profile": map[string]string{"name": "Foo"}})
+	if err != nil || update.PostingJSONMetadata != `{"profile":{"name":"Foo"}}` {
+		t.Errorf("NewAccountUpdate2() = %v, %v", update, err)
+	}
+}
+
+func TestMarshalOperation(t *testing.T) {
+	expiration := h.Time{Time: time.Date(2020, 4, 17, 14, 0, 0, 0, time.UTC)}
+	tests := []struct {
+		name string
+		op   h.Operation
+		want string
+	}{
+		{
+			name: "Vote",
+			op:   &h.VoteOperation{Voter: "foo", Author: "bar", Permlink: "baz", Weight: -500},
+			want: `["vote",{"voter":"foo","author":"bar","permlink":"baz","weight":-500}]`,
+		},
+		{
+			name: "Limit order",
+			op:   &h.LimitOrderCreateOperation{Owner: "foo", OrderID: 7, AmountToSell: asset("1.000 HIVE"), MinToReceive: asset("0.250 HBD"), Expiration: expiration},
+			want: `["limit_order_create",{"owner":"foo","orderid":7,"amount_to_sell":"1.000 HIVE","min_to_receive":"0.250 HBD","fill_or_kill":false,"expiration":"` + expiration.String() + `"}]`,
+		},
+		{
+			name: "Comment options",
+			op: &h.CommentOptionsOperation{
+				Author: "foo", Permlink: "bar", MaxAcceptedPayout: asset("0.000 HBD"), AllowVotes: true,
+				Beneficiaries: []h.Beneficiary{{Account: "baz", Weight: 500}},
+			},
+			want: `["comment_options",{"author":"foo","permlink":"bar","max_accepted_payout":"0.000 HBD","percent_hbd":0,"allow_votes":true,"allow_curation_rewards":false,"extensions":[[0,{"beneficiaries":[{"account":"baz","weight":500}]}]]}]`,
+		},
+		{
+			name: "Feed publish",
+			op:   &h.FeedPublishOperation{Publisher: "foo", ExchangeRate: h.Price{Base: asset("0.250 HBD"), Quote: asset("1.000 HIVE")}},
+			want: `["feed_publish",{"publisher":"foo","exchange_rate":{"base":"0.250 HBD","quote":"1.000 HIVE"}}]`,
+		},
+		{
+			name: "Escrow release",
+			op: &h.EscrowReleaseOperation{
+				From: "foo", To: "bar", Agent: "baz", Who: "baz", Receiver: "bar", EscrowID: 3,
+				HbdAmount: asset("1.000 HBD"), HiveAmount: asset("0.000 HIVE"),
+			},
+			want: `["escrow_release",{"from":"foo","to":"bar","agent":"baz","who":"baz","receiver":"bar","escrow_id":3,"hbd_amount":"1.000 HBD","hive_amount":"0.000 HIVE"}]`,
+		},
+		{
+			name: "Create proposal",
+			op: &h.CreateProposalOperation{
+				Creator: "foo", Receiver: "bar", StartDate: expiration, EndDate: h.Time{Time: expiration.Add(24 * time.Hour)},
+				DailyPay: asset("1.000 HBD"), Subject: "Fund me", Permlink: "fund-me",
+			},
+			want: `["create_proposal",{"creator":"foo","receiver":"bar","start_date":"2020-04-17T14:00:00","end_date":"2020-04-18T14:00:00",` +
+				`"daily_pay":"1.000 HBD","subject":"Fund me","permlink":"fund-me","extensions":[]}]`,
+		},
+		{
+			name: "Custom",
+			op:   &h.CustomOperation{RequiredAuths: []string{"foo"}, ID: 7, Data: "beef"},
+			want: `["custom",{"required_auths":["foo"],"id":7,"data":"beef"}]`,
+		},
+		{
+			name: "Recurrent transfer",
+			op:   &h.RecurrentTransferOperation{From: "foo", To: "bar", Amount: asset("1.000 HBD"), Recurrence: 24, Executions: 3},
+			want: `["recurrent_transfer",{"from":"foo","to":"bar","amount":"1.000 HBD","memo":"","recurrence":24,"executions":3,"extensions":[]}]`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := h.MarshalOperation(tt.op)
+			if err != nil {
+				t.Fatalf("MarshalOperation() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("MarshalOperation() = %s, want %s", got, tt.want)
+			}
+
+			back, err := h.UnmarshalOperation(got)
+			if err != nil {
+				t.Fatalf("UnmarshalOperation() error = %v", err)
+			}
+			again, _ := json.Marshal([]interface{}{back.Type(), back})
+			if string(again) != tt.want {
+				t.Errorf("UnmarshalOperation() round trip = %s, want %s", again, tt.want)
+			}
+		})
+	}
+
+	if _, err := h.UnmarshalOperation([]byte(`["pow",{}]`)); !errors.Is(err, h.ErrUnsupportedOperation) {
+		t.Errorf("UnmarshalOperation() error = %v, want ErrUnsupportedOperation", err)
+	}
+}
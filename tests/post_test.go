@@ -0,0 +1,184 @@
+package gohive
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	h "github.com/nathansenn/go-hive"
+	"github.com/nathansenn/go-hive/mocks"
+	"github.com/stretchr/testify/mock"
+	rpc "github.com/ybbus/jsonrpc"
+)
+
+func TestChain_GetContent(t *testing.T) {
+	mockCall := new(mocks.Caller)
+	output := &rpc.RPCResponse{
+		JSONRPC: "2.0",
+		Result: map[string]interface{}{
+			"id":                   77,
+			"author":               "jrswab",
+			"permlink":             "go-hive",
+			"category":             "hive-dev",
+			"title":                "go-hive",
+			"body":                 "A Go client",
+			"json_metadata":        `{"tags":["hive-dev","go"],"image":["https://example.com/a.png"],"app":"peakd/2021.1"}`,
+			"created":              "2020-03-20T14:00:00",
+			"net_rshares":          "123456789012345",
+			"author_reputation":    "77000000000000",
+			"pending_payout_value": "1.234 HBD",
+			"max_accepted_payout":  "1000000.000 HBD",
+			"percent_hbd":          10000,
+			"beneficiaries":        []interface{}{map[string]interface{}{"account": "hiveio", "weight": 500}},
+			"active_votes": []interface{}{
+				map[string]interface{}{
+					"voter":      "hiveio",
+					"weight":     12345,
+					"rshares":    "123456789012345",
+					"percent":    10000,
+					"reputation": 0,
+					"time":       "2020-03-20T14:05:00",
+				},
+			},
+		},
+		ID: 0,
+	}
+	output2 := &rpc.RPCResponse{
+		JSONRPC: "2.0",
+		Result:  map[string]interface{}{"id": 0, "author": "", "permlink": ""},
+		ID:      0,
+	}
+	mockCall.On("CallRaw", mock.Anything).Return(output, nil).Once()
+	mockCall.On("CallRaw", mock.Anything).Return(nil, fmt.Errorf("fake error message")).Once()
+	mockCall.On("CallRaw", mock.Anything).Return(output2, nil).Once()
+
+	tests := []struct {
+		name    string
+		want    *h.Post
+		wantErr bool
+	}{
+		{
+			name: "Get post",
+			want: &h.Post{
+				ID:       77,
+				Author:   "jrswab",
+				Permlink: "go-hive",
+				Category: "hive-dev",
+				Title:    "go-hive",
+				Body:     "A Go client",
+				JSONMetadata: h.PostMetadata{
+					Tags:  []string{"hive-dev", "go"},
+					Image: []string{"https://example.com/a.png"},
+					App:   "peakd/2021.1",
+					Raw:   json.RawMessage(`{"tags":["hive-dev","go"],"image":["https://example.com/a.png"],"app":"peakd/2021.1"}`),
+				},
+				Created:            h.Time{Time: time.Date(2020, 3, 20, 14, 0, 0, 0, time.UTC)},
+				NetRshares:         "123456789012345",
+				AuthorReputation:   "77000000000000",
+				PendingPayoutValue: h.NewAsset(1.234, h.HBD),
+				MaxAcceptedPayout:  h.NewAsset(1000000, h.HBD),
+				PercentHbd:         10000,
+				Beneficiaries:      []h.Beneficiary{{Account: "hiveio", Weight: 500}},
+				ActiveVotes: []h.ActiveVote{{
+					Voter:      "hiveio",
+					Weight:     "12345",
+					Rshares:    "123456789012345",
+					Percent:    10000,
+					Reputation: "0",
+					Time:       h.Time{Time: time.Date(2020, 3, 20, 14, 5, 0, 0, time.UTC)},
+				}},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "Get call error message",
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Post not found",
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &h.Client{
+				URL:    "https://api.hive.blog",
+				Client: mockCall,
+			}
+			got, err := c.GetContent("jrswab", "go-hive")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Chain.GetContent() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Chain.GetContent() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChain_GetPost(t *testing.T) {
+	mockCall := new(mocks.Caller)
+	output := &rpc.RPCResponse{
+		JSONRPC: "2.0",
+		Result: map[string]interface{}{
+			"post_id":       77,
+			"author":        "jrswab",
+			"permlink":      "go-hive",
+			"json_metadata": map[string]interface{}{"tags": []interface{}{"go"}, "app": "ecency/3.0"},
+			"is_paidout":    true,
+			"payout":        12.5,
+			"active_votes":  []interface{}{map[string]interface{}{"voter": "hiveio", "rshares": 1000}},
+		},
+		ID: 0,
+	}
+	mockCall.On("CallRaw", mock.Anything).Return(output, nil).Once()
+
+	c := &h.Client{
+		URL:    "https://api.hive.blog",
+		Client: mockCall,
+	}
+	got, err := c.GetPost("jrswab", "go-hive", "")
+	if err != nil {
+		t.Fatalf("Chain.GetPost() error = %v", err)
+	}
+	if got.PostID != 77 || !got.IsPaidout || got.Payout != 12.5 {
+		t.Errorf("Chain.GetPost() = %+v", got)
+	}
+	if !reflect.DeepEqual(got.JSONMetadata.Tags, []string{"go"}) || got.JSONMetadata.App != "ecency/3.0" {
+		t.Errorf("Chain.GetPost() metadata = %+v", got.JSONMetadata)
+	}
+	if len(got.ActiveVotes) != 1 || got.ActiveVotes[0].Rshares != "1000" {
+		t.Errorf("Chain.GetPost() votes = %+v", got.ActiveVotes)
+	}
+}
+
+func TestPostMetadata_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		tags []string
+		app  string
+	}{
+		{name: "Encoded string", in: `"{\"tags\":[\"go\"],\"app\":\"peakd\"}"`, tags: []string{"go"}, app: "peakd"},
+		{name: "Object", in: `{"tags":["go","hive"]}`, tags: []string{"go", "hive"}, app: ""},
+		{name: "Single tag", in: `{"tags":"go"}`, tags: []string{"go"}, app: ""},
+		{name: "Not JSON", in: `"not json"`, tags: nil, app: ""},
+		{name: "Empty string", in: `""`, tags: nil, app: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got h.PostMetadata
+			if err := json.Unmarshal([]byte(tt.in), &got); err != nil {
+				t.Fatalf("PostMetadata.UnmarshalJSON() error = %v", err)
+			}
+			if !reflect.DeepEqual(got.Tags, tt.tags) || got.App != tt.app {
+				t.Errorf("PostMetadata.UnmarshalJSON() = %+v, want tags %v app %q", got, tt.tags, tt.app)
+			}
+		})
+	}
+}
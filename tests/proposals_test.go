@@ -0,0 +1,169 @@
+package gohive
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	h "github.com/nathansenn/go-hive"
+	"github.com/nathansenn/go-hive/mocks"
+	"github.com/stretchr/testify/mock"
+	rpc "github.com/ybbus/jsonrpc"
+)
+
+func TestChain_ListProposals(t *testing.T) {
+	mockCall := new(mocks.Caller)
+	output := &rpc.RPCResponse{
+		JSONRPC: "2.0",
+		Result: []interface{}{
+			map[string]interface{}{
+				"id":          1,
+				"proposal_id": 1,
+				"creator":     "jrswab",
+				"receiver":    "jrswab",
+				"start_date":  "2020-04-01T00:00:00",
+				"end_date":    "2020-10-01T00:00:00",
+				"daily_pay":   "100.000 HBD",
+				"subject":     "go-hive",
+				"permlink":    "go-hive-proposal",
+				"total_votes": "12345678901234",
+				"status":      "active",
+			},
+		},
+		ID: 0,
+	}
+	output2 := &rpc.RPCResponse{
+		JSONRPC: "2.0",
+		Error:   &rpc.RPCError{Code: 500, Message: "some error"},
+		ID:      0,
+	}
+	mockCall.On("CallRaw", mock.Anything).Return(output, nil).Once()
+	mockCall.On("CallRaw", mock.Anything).Return(nil, fmt.Errorf("fake error message")).Once()
+	mockCall.On("CallRaw", mock.Anything).Return(output2, nil).Once()
+
+	tests := []struct {
+		name    string
+		limit   int
+		want    []h.Proposal
+		wantErr bool
+	}{
+		{
+			name:  "Get proposals",
+			limit: 10,
+			want: []h.Proposal{{
+				ID:         1,
+				ProposalID: 1,
+				Creator:    "jrswab",
+				Receiver:   "jrswab",
+				StartDate:  h.Time{Time: time.Date(2020, 4, 1, 0, 0, 0, 0, time.UTC)},
+				EndDate:    h.Time{Time: time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC)},
+				DailyPay:   h.NewAsset(100, h.HBD),
+				Subject:    "go-hive",
+				Permlink:   "go-hive-proposal",
+				TotalVotes: "12345678901234",
+				Status:     "active",
+			}},
+			wantErr: false,
+		},
+		{
+			name:    "Get call error message",
+			limit:   10,
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Get responce error message",
+			limit:   10,
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Limit too large",
+			limit:   1001,
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &h.Client{
+				URL:    "https://api.hive.blog",
+				Client: mockCall,
+			}
+			got, err := c.ListProposals(h.ProposalsByCreator, h.Ascending, h.ProposalsActive, []interface{}{""}, tt.limit)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Chain.ListProposals() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Chain.ListProposals() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChain_FindProposals(t *testing.T) {
+	c := &h.Client{
+		URL:    "https://api.hive.blog",
+		Client: new(mocks.Caller),
+	}
+	if _, err := c.FindProposals(); err == nil {
+		t.Errorf("Chain.FindProposals() without ids should fail")
+	}
+}
+
+func TestSimulateFunding(t *testing.T) {
+	now := time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC)
+	proposal := func(id int64, votes string, pay float64, end time.Time) h.Proposal {
+		return h.Proposal{
+			ProposalID: id,
+			TotalVotes: json.Number(votes),
+			DailyPay:   h.NewAsset(pay, h.HBD),
+			StartDate:  h.Time{Time: time.Date(2020, 4, 1, 0, 0, 0, 0, time.UTC)},
+			EndDate:    h.Time{Time: end},
+		}
+	}
+	end := time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC)
+	returnProposal := proposal(0, "500", 1000000, end)
+	first := proposal(1, "1000", 600, end)
+	second := proposal(2, "800", 600, end)
+	belowReturn := proposal(3, "400", 100, end)
+	expired := proposal(4, "2000", 100, now)
+
+	tests := []struct {
+		name      string
+		proposals []h.Proposal
+		want      []h.ProposalFunding
+		wantErr   bool
+	}{
+		{
+			name:      "Budget runs out and threshold applies",
+			proposals: []h.Proposal{returnProposal, second, belowReturn, expired, first},
+			want: []h.ProposalFunding{
+				{Proposal: first, Paid: h.NewAsset(600, h.HBD)},
+				{Proposal: second, Paid: h.NewAsset(400, h.HBD)},
+			},
+			wantErr: false,
+		},
+		{
+			name:      "Invalid votes",
+			proposals: []h.Proposal{proposal(5, "many", 1, end)},
+			want:      nil,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := h.SimulateFunding(tt.proposals, h.NewAsset(100000, h.HBD), now)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SimulateFunding() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SimulateFunding() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
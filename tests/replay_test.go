@@ -0,0 +1,152 @@
+package gohive
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	h "github.com/nathansenn/go-hive"
+	"github.com/nathansenn/go-hive/mocks"
+	"github.com/nathansenn/go-hive/replay"
+	"github.com/stretchr/testify/mock"
+	rpc "github.com/ybbus/jsonrpc"
+)
+
+func TestReplay_RecordAndReplay(t *testing.T) {
+	mockCall := new(mocks.Caller)
+	accMock := &h.AccountData{ID: 1111, Mined: false, Name: "jrswab"}
+	var number json.Number
+	number = "1111"
+	output := &rpc.RPCResponse{
+		JSONRPC: "2.0",
+		Result:  []interface{}{accMock},
+		ID:      0,
+	}
+	output2 := &rpc.RPCResponse{
+		JSONRPC: "2.0",
+		Result:  number,
+		ID:      0,
+	}
+	output3 := &rpc.RPCResponse{
+		JSONRPC: "2.0",
+		Error:   &rpc.RPCError{Code: 500, Message: "some error"},
+		ID:      0,
+	}
+	mockCall.On("CallRaw", mock.Anything).Return(output, nil).Once()
+	mockCall.On("CallRaw", mock.Anything).Return(output2, nil).Once()
+	mockCall.On("CallRaw", mock.Anything).Return(output3, nil).Once()
+
+	rec := replay.NewRecorder(mockCall)
+	c := &h.Client{URL: "https://api.hive.blog", Client: rec}
+	c.GetAccounts("jrswab")
+	c.GetAccountCount()
+	c.GetAccountBandwidth("jrswab")
+
+	path := filepath.Join(t.TempDir(), "fixture.json")
+	if err := rec.Save(path); err != nil {
+		t.Fatalf("Recorder.Save() error = %v", err)
+	}
+
+	r, err := replay.Load(path)
+	if err != nil {
+		t.Fatalf("replay.Load() error = %v", err)
+	}
+	c.Client = r
+
+	accs, err := c.GetAccounts("jrswab")
+	if err != nil {
+		t.Errorf("Chain.GetAccounts() error = %v", err)
+	} else if !reflect.DeepEqual(accs, &[]h.AccountData{*accMock}) {
+		t.Errorf("Chain.GetAccounts() = %v, want %v", accs, accMock)
+	}
+
+	count, err := c.GetAccountCount()
+	if err != nil || count != 1111 {
+		t.Errorf("Chain.GetAccountCount() = %v, %v, want 1111", count, err)
+	}
+
+	if _, err := c.GetAccountBandwidth("jrswab"); err == nil {
+		t.Errorf("Chain.GetAccountBandwidth() want recorded error")
+	}
+
+	if unused := r.Unused(); len(unused) != 0 {
+		t.Errorf("Replayer.Unused() = %v, want none", unused)
+	}
+}
+
+func TestReplayer_CallRaw(t *testing.T) {
+	recorded := []replay.Interaction{
+		{
+			Method:   "get_account_count",
+			Params:   json.RawMessage(`[[]]`),
+			Response: &rpc.RPCResponse{JSONRPC: "2.0", Result: json.Number("1111")},
+		},
+	}
+	mockCall := new(mocks.Caller)
+	mockCall.On("CallRaw", mock.Anything).Return(nil, fmt.Errorf("fake error message"))
+
+	tests := []struct {
+		name    string
+		strict  bool
+		next    h.Caller
+		method  string
+		calls   int
+		wantErr bool
+	}{
+		{
+			name:    "Replay recorded call",
+			strict:  true,
+			method:  "get_account_count",
+			calls:   1,
+			wantErr: false,
+		},
+		{
+			name:    "Strict fails on repeated call",
+			strict:  true,
+			method:  "get_account_count",
+			calls:   2,
+			wantErr: true,
+		},
+		{
+			name:    "Non strict reuses last response",
+			strict:  false,
+			method:  "get_account_count",
+			calls:   2,
+			wantErr: false,
+		},
+		{
+			name:    "Strict fails on unrecorded call",
+			strict:  true,
+			next:    mockCall,
+			method:  "get_accounts",
+			calls:   1,
+			wantErr: true,
+		},
+		{
+			name:    "Non strict passes unrecorded call on",
+			strict:  false,
+			next:    mockCall,
+			method:  "get_accounts",
+			calls:   1,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := replay.NewReplayer(recorded)
+			r.Strict = tt.strict
+			r.Next = tt.next
+
+			var err error
+			for i := 0; i < tt.calls; i++ {
+				_, err = r.CallRaw(rpc.NewRequest(tt.method, []interface{}{[]string{}}))
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Replayer.CallRaw() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+	mockCall.AssertNumberOfCalls(t, "CallRaw", 1)
+}
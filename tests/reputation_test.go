@@ -0,0 +1,127 @@
+package gohive
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"testing"
+
+	h "github.com/nathansenn/go-hive"
+	"github.com/nathansenn/go-hive/mocks"
+	"github.com/stretchr/testify/mock"
+	rpc "github.com/ybbus/jsonrpc"
+)
+
+func TestReputationScore(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  int64
+		want float64
+	}{
+		{name: "New account", raw: 0, want: 25},
+		{name: "Below one billion", raw: 999999999, want: 25},
+		{name: "Ten billion", raw: 10000000000, want: 34},
+		{name: "Typical account", raw: 95832978796820, want: 69.83},
+		{name: "Negative", raw: -10000000000, want: 16},
+		{name: "Largest int64", raw: math.MaxInt64, want: 114.68},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := h.ReputationScore(tt.raw)
+			if math.Abs(got-tt.want) > 0.01 {
+				t.Errorf("ReputationScore() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	big1, _ := new(big.Int).SetString("100000000000000000000000000", 10)
+	if got := h.ReputationScoreBig(big1); math.Abs(got-178) > 0.01 {
+		t.Errorf("ReputationScoreBig() = %v, want 187", got)
+	}
+}
+
+func TestChain_GetAccountReputations(t *testing.T) {
+	mockCall := new(mocks.Caller)
+	output := &rpc.RPCResponse{
+		JSONRPC: "2.0",
+		Result: []interface{}{
+			map[string]interface{}{"account": "jrswab", "reputation": json.Number("95832978796820")},
+			map[string]interface{}{"account": "jrswab-test", "reputation": json.Number("0")},
+			map[string]interface{}{"account": "jrt", "reputation": json.Number("1")},
+		},
+		ID: 0,
+	}
+	output2 := &rpc.RPCResponse{
+		JSONRPC: "2.0",
+		Error:   &rpc.RPCError{Code: 500, Message: "some error"},
+		ID:      0,
+	}
+	mockCall.On("CallRaw", mock.Anything).Return(output, nil).Once()
+	mockCall.On("CallRaw", mock.Anything).Return(nil, fmt.Errorf("fake error message")).Once()
+	mockCall.On("CallRaw", mock.Anything).Return(output2, nil).Once()
+
+	tests := []struct {
+		name    string
+		prefix  string
+		want    []h.AccountReputation
+		wantErr bool
+	}{
+		{
+			name:   "Every account with the prefix",
+			prefix: "jrswab",
+			want: []h.AccountReputation{
+				{Account: "jrswab", Reputation: "95832978796820"},
+				{Account: "jrswab-test", Reputation: "0"},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "Get call error message",
+			prefix:  "jrswab",
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Get responce error message",
+			prefix:  "jrswab",
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &h.Client{
+				URL:    "https://api.hive.blog",
+				Client: mockCall,
+			}
+			got, err := c.GetAccountReputations(tt.prefix, 3)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Chain.GetAccountReputations() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Chain.GetAccountReputations() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChain_GetAccountReputationNotFound(t *testing.T) {
+	mockCall := new(mocks.Caller)
+	output := &rpc.RPCResponse{
+		JSONRPC: "2.0",
+		Result:  []interface{}{&h.AccountReputation{Account: "jrt", Reputation: "1"}},
+		ID:      0,
+	}
+	mockCall.On("CallRaw", mock.Anything).Return(output, nil).Once()
+	mockCall.On("CallRaw", mock.Anything).Return(&rpc.RPCResponse{JSONRPC: "2.0", Result: []interface{}{}}, nil).Once()
+
+	c := &h.Client{URL: "https://api.hive.blog", Client: mockCall}
+	for i := 0; i < 2; i++ {
+		if got, err := c.GetAccountReputation("jrswab"); err == nil {
+			t.Errorf("Chain.GetAccountReputation() = %v, want not found error", got)
+		}
+	}
+}
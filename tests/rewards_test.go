@@ -0,0 +1,249 @@
+package gohive
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	h "github.com/nathansenn/go-hive"
+	"github.com/nathansenn/go-hive/mocks"
+	"github.com/stretchr/testify/mock"
+	rpc "github.com/ybbus/jsonrpc"
+)
+
+var postFund = h.RewardFund{
+	Name:                   "post",
+	RewardBalance:          h.NewAsset(800000, h.HIVE),
+	RecentClaims:           "8000000000000000",
+	PercentCurationRewards: 5000,
+	AuthorRewardCurve:      "linear",
+	CurationRewardCurve:    "linear",
+}
+
+func TestChain_GetRewardFund(t *testing.T) {
+	mockCall := new(mocks.Caller)
+	output := &rpc.RPCResponse{
+		JSONRPC: "2.0",
+		Result: map[string]interface{}{
+			"name":                     "post",
+			"reward_balance":           "800000.000 HIVE",
+			"recent_claims":            "8000000000000000",
+			"percent_curation_rewards": 5000,
+			"author_reward_curve":      "linear",
+			"curation_reward_curve":    "linear",
+		},
+		ID: 0,
+	}
+	output2 := &rpc.RPCResponse{
+		JSONRPC: "2.0",
+		Error:   &rpc.RPCError{Code: 500, Message: "some error"},
+		ID:      0,
+	}
+	mockCall.On("CallRaw", mock.Anything).Return(output, nil).Once()
+	mockCall.On("CallRaw", mock.Anything).Return(nil, fmt.Errorf("fake error message")).Once()
+	mockCall.On("CallRaw", mock.Anything).Return(output2, nil).Once()
+
+	tests := []struct {
+		name    string
+		want    *h.RewardFund
+		wantErr bool
+	}{
+		{
+			name:    "Get reward fund",
+			want:    &postFund,
+			wantErr: false,
+		},
+		{
+			name:    "Get call error message",
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Get responce error message",
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &h.Client{
+				URL:    "https://api.hive.blog",
+				Client: mockCall,
+			}
+			got, err := c.GetRewardFund("post")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Chain.GetRewardFund() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Chain.GetRewardFund() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestManabar_Current(t *testing.T) {
+	now := time.Date(2020, 3, 20, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		bar  h.Manabar
+		want int64
+	}{
+		{name: "Regenerates", bar: h.Manabar{CurrentMana: "0", LastUpdateTime: now.Add(-60 * time.Hour).Unix()}, want: 500000000000},
+		{name: "Capped at max", bar: h.Manabar{CurrentMana: "900000000000", LastUpdateTime: now.Add(-60 * time.Hour).Unix()}, want: 1000000000000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.bar.Current(1000000000000, now)
+			if err != nil {
+				t.Fatalf("Manabar.Current() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Manabar.Current() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAccountData_EffectiveVestingShares(t *testing.T) {
+	// fc encodes int64 values above INT32_MAX as strings.
+	data := `{
+		"vesting_shares": "30000000.000000 VESTS",
+		"vesting_withdraw_rate": "3000000.000000 VESTS",
+		"to_withdraw": "26000000000000",
+		"withdrawn": 24000000000000
+	}`
+	var acc h.AccountData
+	if err := json.Unmarshal([]byte(data), &acc); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+
+	got, err := acc.EffectiveVestingShares()
+	if err != nil {
+		t.Fatalf("AccountData.EffectiveVestingShares() error = %v", err)
+	}
+	if want := asset("28000000.000000 VESTS"); got != want {
+		t.Errorf("AccountData.EffectiveVestingShares() = %v, want %v", got, want)
+	}
+}
+
+func TestChain_GetAccountsEffectiveVestingShares(t *testing.T) {
+	// Excerpt of a condenser_api.get_accounts response.
+	data := `[{
+		"id": 1370484,
+		"name": "jrswab",
+		"recovery_account": "hiveio",
+		"vesting_shares": "30000000.000000 VESTS",
+		"delegated_vesting_shares": "1000000.000000 VESTS",
+		"received_vesting_shares": "4000000.000000 VESTS",
+		"vesting_withdraw_rate": "0.000000 VESTS",
+		"next_vesting_withdrawal": "1969-12-31T23:59:59",
+		"to_withdraw": 0,
+		"withdrawn": 0
+	}]`
+	var result interface{}
+	if err := json.Unmarshal([]byte(data), &result); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	mockCall := new(mocks.Caller)
+	output := &rpc.RPCResponse{JSONRPC: "2.0", Result: result, ID: 0}
+	mockCall.On("CallRaw", mock.Anything).Return(output, nil).Once()
+
+	c := &h.Client{
+		URL:    "https://api.hive.blog",
+		Client: mockCall,
+	}
+	accs, err := c.GetAccounts("jrswab")
+	if err != nil {
+		t.Fatalf("Chain.GetAccounts() error = %v", err)
+	}
+	acc := (*accs)[0]
+	if acc.RecoveryAccount != "hiveio" {
+		t.Errorf("AccountData.RecoveryAccount = %q, want hiveio", acc.RecoveryAccount)
+	}
+	got, err := acc.EffectiveVestingShares()
+	if err != nil {
+		t.Fatalf("AccountData.EffectiveVestingShares() error = %v", err)
+	}
+	if want := asset("33000000.000000 VESTS"); got != want {
+		t.Errorf("AccountData.EffectiveVestingShares() = %v, want %v", got, want)
+	}
+}
+
+func TestVoteValue(t *testing.T) {
+	now := time.Date(2020, 3, 20, 0, 0, 0, 0, time.UTC)
+	acc := h.AccountData{
+		VestingShares:          "1000000.000000 VESTS",
+		DelegatedVestingShares: "0.000000 VESTS",
+		ReceivedVestingShares:  "0.000000 VESTS",
+		VestingWithdrawRate:    "0.000000 VESTS",
+		VotingManabar:          h.Manabar{CurrentMana: "1000000000000", LastUpdateTime: now.Unix()},
+	}
+
+	tests := []struct {
+		name    string
+		weight  int
+		rshares int64
+		want    string
+		wantErr bool
+	}{
+		{name: "Full vote", weight: 10000, rshares: 19950000000, want: "0.498 HBD", wantErr: false},
+		{name: "Half vote", weight: 5000, rshares: 9950000000, want: "0.248 HBD", wantErr: false},
+		{name: "Downvote", weight: -10000, rshares: -19950000000, want: "-0.498 HBD", wantErr: false},
+		{name: "Weight too large", weight: 10001, rshares: 0, want: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rshares, err := acc.VoteRshares(tt.weight, now)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("AccountData.VoteRshares() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if rshares != tt.rshares {
+				t.Errorf("AccountData.VoteRshares() = %d, want %d", rshares, tt.rshares)
+			}
+			if tt.wantErr {
+				return
+			}
+			got, err := h.VoteValue(acc, tt.weight, now, postFund, median)
+			if err != nil {
+				t.Fatalf("VoteValue() error = %v", err)
+			}
+			if got.String() != tt.want {
+				t.Errorf("VoteValue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEstimatePayout(t *testing.T) {
+	p := h.Post{
+		PercentHbd:        10000,
+		MaxAcceptedPayout: h.NewAsset(1000000, h.HBD),
+		Beneficiaries:     []h.Beneficiary{{Account: "hiveio", Weight: 1000}},
+		ActiveVotes:       []h.ActiveVote{{Voter: "a", Rshares: "60000000000"}, {Voter: "b", Rshares: "20000000000"}},
+	}
+	got, err := h.EstimatePayout(p, postFund, median)
+	if err != nil {
+		t.Fatalf("EstimatePayout() error = %v", err)
+	}
+	want := h.PayoutEstimate{
+		Total:         h.NewAsset(2, h.HBD),
+		Curators:      h.NewAsset(1, h.HBD),
+		Beneficiaries: []h.BeneficiaryPayout{{Account: "hiveio", Amount: h.NewAsset(0.1, h.HBD)}},
+		Author:        h.NewAsset(0.9, h.HBD),
+		AuthorHBD:     h.NewAsset(0.45, h.HBD),
+		AuthorHP:      h.NewAsset(0.45, h.HBD),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("EstimatePayout() = %+v, want %+v", got, want)
+	}
+
+	p.MaxAcceptedPayout = h.NewAsset(1, h.HBD)
+	got, err = h.EstimatePayout(p, postFund, median)
+	if err != nil || got.Total.String() != "1.000 HBD" {
+		t.Errorf("EstimatePayout() total = %v, %v, want the 1.000 HBD max accepted payout", got.Total, err)
+	}
+}
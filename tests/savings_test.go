@@ -0,0 +1,180 @@
+package gohive
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	h "github.com/nathansenn/go-hive"
+	"github.com/nathansenn/go-hive/hivetest"
+	"github.com/nathansenn/go-hive/mocks"
+	"github.com/stretchr/testify/mock"
+	rpc "github.com/ybbus/jsonrpc"
+)
+
+func TestChain_GetSavingsWithdrawFrom(t *testing.T) {
+	mockCall := new(mocks.Caller)
+	output := &rpc.RPCResponse{
+		JSONRPC: "2.0",
+		Result: []interface{}{
+			map[string]interface{}{
+				"id":         4,
+				"from":       "jrswab",
+				"to":         "hiveio",
+				"memo":       "rent",
+				"request_id": 101,
+				"amount":     "10.000 HBD",
+				"complete":   "2020-03-23T14:00:00",
+			},
+		},
+		ID: 0,
+	}
+	output2 := &rpc.RPCResponse{
+		JSONRPC: "2.0",
+		Error:   &rpc.RPCError{Code: 500, Message: "some error"},
+		ID:      0,
+	}
+	mockCall.On("CallRaw", mock.Anything).Return(output, nil).Once()
+	mockCall.On("CallRaw", mock.Anything).Return(nil, fmt.Errorf("fake error message")).Once()
+	mockCall.On("CallRaw", mock.Anything).Return(output2, nil).Once()
+
+	tests := []struct {
+		name    string
+		want    []h.SavingsWithdraw
+		wantErr bool
+	}{
+		{
+			name: "Get withdrawals",
+			want: []h.SavingsWithdraw{{
+				ID:        4,
+				From:      "jrswab",
+				To:        "hiveio",
+				Memo:      "rent",
+				RequestID: 101,
+				Amount:    h.Asset{Amount: 10000, Precision: 3, Symbol: h.HBD},
+				Complete:  h.Time{Time: time.Date(2020, 3, 23, 14, 0, 0, 0, time.UTC)},
+			}},
+			wantErr: false,
+		},
+		{
+			name:    "Get call error message",
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Get responce error message",
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &h.Client{
+				URL:    "https://api.hive.blog",
+				Client: mockCall,
+			}
+			got, err := c.GetSavingsWithdrawFrom("jrswab")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Chain.GetSavingsWithdrawFrom() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Chain.GetSavingsWithdrawFrom() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAccountData_SavingsInterest(t *testing.T) {
+	now := time.Date(2020, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		acc     h.AccountData
+		want    h.Asset
+		wantErr bool
+	}{
+		{
+			name: "Interest since the last update",
+			acc: h.AccountData{
+				SavingsHbdBalance:           "1000.000 HBD",
+				SavingsHbdSeconds:           "0",
+				SavingsHbdSecondsLastUpdate: "2020-03-02T00:00:00",
+			},
+			want:    h.Asset{Amount: 16438, Precision: 3, Symbol: h.HBD},
+			wantErr: false,
+		},
+		{
+			name: "Interest with accrued seconds",
+			acc: h.AccountData{
+				SavingsHbdBalance:           "1000.000 HBD",
+				SavingsHbdSeconds:           "31536000000000",
+				SavingsHbdSecondsLastUpdate: "2020-03-02T00:00:00",
+			},
+			want:    h.Asset{Amount: 216438, Precision: 3, Symbol: h.HBD},
+			wantErr: false,
+		},
+		{
+			name: "Invalid seconds",
+			acc: h.AccountData{
+				SavingsHbdBalance:           "1000.000 HBD",
+				SavingsHbdSeconds:           "lots",
+				SavingsHbdSecondsLastUpdate: "2020-03-02T00:00:00",
+			},
+			want:    h.Asset{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.acc.SavingsInterest(2000, now)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("AccountData.SavingsInterest() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("AccountData.SavingsInterest() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChain_GetDynamicGlobalPropertiesParams(t *testing.T) {
+	mockCall := new(mocks.Caller)
+	output := &rpc.RPCResponse{
+		JSONRPC: "2.0",
+		Result:  map[string]interface{}{"head_block_number": 1},
+		ID:      0,
+	}
+	mockCall.On("CallRaw", emptyParams).Return(output, nil).Once()
+
+	c := &h.Client{
+		URL:    "https://api.hive.blog",
+		Client: mockCall,
+	}
+	if _, err := c.GetDynamicGlobalProperties(); err != nil {
+		t.Errorf("Chain.GetDynamicGlobalProperties() error = %v", err)
+	}
+	mockCall.AssertExpectations(t)
+}
+
+func TestChain_GetDynamicGlobalProperties(t *testing.T) {
+	s := hivetest.NewServer()
+	defer s.Close()
+
+	for _, api := range []h.APIMode{h.CondenserAPI, h.AppbaseAPI} {
+		c := h.NewClient(s.URL)
+		c.API = api
+		got, err := c.GetDynamicGlobalProperties()
+		if err != nil {
+			t.Fatalf("Chain.GetDynamicGlobalProperties() error = %v", err)
+		}
+		if got.HbdInterestRate != 2000 {
+			t.Errorf("Chain.GetDynamicGlobalProperties() hbd_interest_rate = %d, want 2000", got.HbdInterestRate)
+		}
+		if got.CurrentHbdSupply.String() != "30000000.000 HBD" {
+			t.Errorf("Chain.GetDynamicGlobalProperties() current_hbd_supply = %s, want 30000000.000 HBD", got.CurrentHbdSupply)
+		}
+	}
+}
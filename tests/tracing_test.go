@@ -0,0 +1,98 @@
+package gohive
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	h "github.com/nathansenn/go-hive"
+	"github.com/nathansenn/go-hive/mocks"
+	"github.com/nathansenn/go-hive/tracing"
+	"github.com/stretchr/testify/mock"
+	rpc "github.com/ybbus/jsonrpc"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracing_Instrument(t *testing.T) {
+	mockCall := new(mocks.Caller)
+	accMock := &h.AccountData{ID: 1111, Mined: false, Name: "jrswab"}
+	output := &rpc.RPCResponse{
+		JSONRPC: "2.0",
+		Result:  []interface{}{accMock},
+		ID:      0,
+	}
+	output2 := &rpc.RPCResponse{
+		JSONRPC: "2.0",
+		Error:   &rpc.RPCError{Code: 500, Message: "some error"},
+		ID:      0,
+	}
+	mockCall.On("CallRaw", mock.Anything).Return(output, nil).Once()
+	mockCall.On("CallRaw", mock.Anything).Return(nil, fmt.Errorf("fake error message")).Once()
+	mockCall.On("CallRaw", mock.Anything).Return(output2, nil).Once()
+
+	tests := []struct {
+		name      string
+		wantCode  codes.Code
+		wantAttrs []attribute.KeyValue
+	}{
+		{
+			name:     "Successful call",
+			wantCode: codes.Unset,
+			wantAttrs: []attribute.KeyValue{
+				tracing.AttrMethod.String("get_accounts"),
+				tracing.AttrNode.String("https://api.hive.blog"),
+				tracing.AttrParamsSize.Int(12),
+			},
+		},
+		{
+			name:     "Get call error message",
+			wantCode: codes.Error,
+		},
+		{
+			name:     "Get responce error message",
+			wantCode: codes.Error,
+			wantAttrs: []attribute.KeyValue{
+				tracing.AttrErrorCode.Int(500),
+				tracing.AttrErrorMessage.String("some error"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := tracetest.NewSpanRecorder()
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec))
+
+			c := &h.Client{URL: "https://api.hive.blog", Client: mockCall}
+			tracing.Instrument(c, tp.Tracer("test"))
+
+			ctx, parent := tp.Tracer("test").Start(context.Background(), "parent")
+			c.WithContext(ctx).GetAccounts("jrswab")
+			parent.End()
+
+			spans := rec.Ended()
+			if len(spans) != 2 {
+				t.Fatalf("tracing.Instrument() recorded %v spans, want 2", len(spans))
+			}
+			span := spans[0]
+			if span.Parent().SpanID() != parent.SpanContext().SpanID() {
+				t.Errorf("tracing.Instrument() span is not a child of the context span")
+			}
+			if span.Status().Code != tt.wantCode {
+				t.Errorf("tracing.Instrument() status = %v, want %v", span.Status().Code, tt.wantCode)
+			}
+
+			attrs := map[attribute.Key]attribute.Value{}
+			for _, a := range span.Attributes() {
+				attrs[a.Key] = a.Value
+			}
+			for _, want := range tt.wantAttrs {
+				if got := attrs[want.Key]; got != want.Value {
+					t.Errorf("tracing.Instrument() %v = %v, want %v", want.Key, got.Emit(), want.Value.Emit())
+				}
+			}
+		})
+	}
+}
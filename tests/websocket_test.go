@@ -0,0 +1,183 @@
+package gohive
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	h "github.com/nathansenn/go-hive"
+	rpc "github.com/ybbus/jsonrpc"
+)
+
+// newEchoServer starts a WebSocket JSON-RPC server answering every request
+// with its first param, or 1111 for get_account_count. The connection is closed after closeAfter responses when it is above zero.
+func newEchoServer(t *testing.T, closeAfter int) *httptest.Server {
+	upgrader := websocket.Upgrader{}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("upgrade: %v", err)
+			return
+		}
+		defer conn.Close()
+
+		var writeMu sync.Mutex
+		var wg sync.WaitGroup
+		for n := 0; closeAfter == 0 || n < closeAfter; n++ {
+			var req struct {
+				Method string        `json:"method"`
+				Params []interface{} `json:"params"`
+				ID     int           `json:"id"`
+			}
+			if err := conn.ReadJSON(&req); err != nil {
+				break
+			}
+
+			resp := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+			if req.Method == "get_account_count" {
+				resp["result"] = 1111
+			} else if len(req.Params) > 0 {
+				resp["result"] = req.Params[0]
+			}
+
+			// Answer out of order to check responses are matched by id.
+			wg.Add(1)
+			go func(delay time.Duration) {
+				defer wg.Done()
+				time.Sleep(delay)
+				writeMu.Lock()
+				defer writeMu.Unlock()
+				conn.WriteJSON(resp)
+			}(time.Duration(10-n%10) * time.Millisecond)
+		}
+		wg.Wait()
+	}))
+}
+
+func wsURL(s *httptest.Server) string {
+	return "ws" + strings.TrimPrefix(s.URL, "http")
+}
+
+func TestNewClient_WebSocket(t *testing.T) {
+	c := h.NewClient("wss://api.hive.blog")
+	if _, ok := c.Client.(*h.WSCaller); !ok {
+		t.Errorf("NewClient() Client = %T, want *gohive.WSCaller", c.Client)
+	}
+}
+
+func TestWSCaller_CallRaw(t *testing.T) {
+	tests := []struct {
+		name       string
+		closeAfter int
+		calls      int
+		concurrent bool
+	}{
+		{
+			name:       "Concurrent calls on one connection",
+			closeAfter: 0,
+			calls:      20,
+			concurrent: true,
+		},
+		{
+			name:       "Reconnect after the node closes the connection",
+			closeAfter: 1,
+			calls:      3,
+			concurrent: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newEchoServer(t, tt.closeAfter)
+			defer s.Close()
+
+			w := h.NewWSCaller(wsURL(s))
+			defer w.Close()
+
+			call := func(n int) {
+				req := rpc.NewRequest("echo", n)
+				req.ID = 7
+				resp, err := w.CallRaw(req)
+				if err != nil {
+					t.Errorf("WSCaller.CallRaw() error = %v", err)
+					return
+				}
+				got, err := resp.GetInt()
+				if err != nil || got != int64(n) {
+					t.Errorf("WSCaller.CallRaw() = %v, want %v", resp.Result, n)
+				}
+				if resp.ID != 7 {
+					t.Errorf("WSCaller.CallRaw() id = %v, want 7", resp.ID)
+				}
+			}
+
+			var wg sync.WaitGroup
+			for i := 0; i < tt.calls; i++ {
+				if !tt.concurrent {
+					call(i)
+					// Let the read loop notice the closed connection.
+					time.Sleep(50 * time.Millisecond)
+					continue
+				}
+				wg.Add(1)
+				go func(n int) {
+					defer wg.Done()
+					call(n)
+				}(i)
+			}
+			wg.Wait()
+		})
+	}
+}
+
+func TestWSCaller_Client(t *testing.T) {
+	s := newEchoServer(t, 0)
+	defer s.Close()
+
+	c := h.NewClient(wsURL(s))
+	defer c.Client.(*h.WSCaller).Close()
+
+	got, err := c.GetAccountCount()
+	if err != nil {
+		t.Errorf("Chain.GetAccountCount() error = %v", err)
+		return
+	}
+	if got != 1111 {
+		t.Errorf("Chain.GetAccountCount() = %v, want 1111", got)
+	}
+}
+
+func TestWSCaller_DeadlineNotReused(t *testing.T) {
+	s := newEchoServer(t, 0)
+	defer s.Close()
+	var dials int32
+	echo := s.Config.Handler
+	s.Config.Handler = http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&dials, 1)
+		echo.ServeHTTP(rw, r)
+	})
+
+	w := h.NewWSCaller(wsURL(s))
+	w.Timeout = 0
+	defer w.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if _, err := w.CallRawContext(ctx, rpc.NewRequest("echo", 1)); err != nil {
+		t.Fatalf("WSCaller.CallRawContext() error = %v", err)
+	}
+
+	// The deadline of the first call has passed; a call without one must still write.
+	time.Sleep(150 * time.Millisecond)
+	if _, err := w.CallRaw(rpc.NewRequest("echo", 2)); err != nil {
+		t.Errorf("WSCaller.CallRaw() error = %v", err)
+	}
+	if got := atomic.LoadInt32(&dials); got != 1 {
+		t.Errorf("WSCaller dialed %v connections, want 1", got)
+	}
+}
@@ -0,0 +1,175 @@
+package gohive
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	h "github.com/nathansenn/go-hive"
+	"github.com/nathansenn/go-hive/mocks"
+	"github.com/stretchr/testify/mock"
+	rpc "github.com/ybbus/jsonrpc"
+)
+
+func TestChain_GetWithdrawRoutes(t *testing.T) {
+	mockCall := new(mocks.Caller)
+	output := &rpc.RPCResponse{
+		JSONRPC: "2.0",
+		Result: []interface{}{
+			map[string]interface{}{
+				"id":           3,
+				"from_account": "jrswab",
+				"to_account":   "hiveio",
+				"percent":      2500,
+				"auto_vest":    true,
+			},
+		},
+		ID: 0,
+	}
+	output2 := &rpc.RPCResponse{
+		JSONRPC: "2.0",
+		Error:   &rpc.RPCError{Code: 500, Message: "some error"},
+		ID:      0,
+	}
+	mockCall.On("CallRaw", mock.Anything).Return(output, nil).Once()
+	mockCall.On("CallRaw", mock.Anything).Return(nil, fmt.Errorf("fake error message")).Once()
+	mockCall.On("CallRaw", mock.Anything).Return(output2, nil).Once()
+
+	tests := []struct {
+		name    string
+		want    []h.WithdrawRoute
+		wantErr bool
+	}{
+		{
+			name:    "Get routes",
+			want:    []h.WithdrawRoute{{ID: 3, FromAccount: "jrswab", ToAccount: "hiveio", Percent: 2500, AutoVest: true}},
+			wantErr: false,
+		},
+		{
+			name:    "Get call error message",
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Get responce error message",
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &h.Client{
+				URL:    "https://api.hive.blog",
+				Client: mockCall,
+			}
+			got, err := c.GetWithdrawRoutes("jrswab", h.WithdrawRoutesOutgoing)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Chain.GetWithdrawRoutes() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Chain.GetWithdrawRoutes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAccountData_PowerDownSchedule(t *testing.T) {
+	vests := func(amount int64) h.Asset {
+		return h.Asset{Amount: amount, Precision: 6, Symbol: h.VESTS}
+	}
+	start := time.Date(2020, 3, 20, 14, 0, 0, 0, time.UTC)
+	routes := []h.WithdrawRoute{
+		{FromAccount: "jrswab", ToAccount: "hiveio", Percent: 2500, AutoVest: true},
+		{FromAccount: "someone", ToAccount: "jrswab", Percent: 10000},
+	}
+
+	tests := []struct {
+		name    string
+		acc     h.AccountData
+		want    []h.PowerDownPayment
+		wantErr bool
+	}{
+		{
+			name: "Last payment is the remainder",
+			acc: h.AccountData{
+				Name:                "jrswab",
+				NextVestingWithdraw: "2020-03-20T14:00:00",
+				VestingWithdrawRate: "1.000000 VESTS",
+				ToWithdraw:          "13000000",
+				Withdrawn:           "11500000",
+			},
+			want: []h.PowerDownPayment{
+				{
+					Date:     start,
+					Amount:   vests(1000000),
+					Routes:   []h.PowerDownRoute{{ToAccount: "hiveio", Amount: vests(250000), AutoVest: true}},
+					Retained: vests(750000),
+				},
+				{
+					Date:     start.Add(7 * 24 * time.Hour),
+					Amount:   vests(500000),
+					Routes:   []h.PowerDownRoute{{ToAccount: "hiveio", Amount: vests(125000), AutoVest: true}},
+					Retained: vests(375000),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Not powering down",
+			acc: h.AccountData{
+				Name:                "jrswab",
+				NextVestingWithdraw: "1969-12-31T23:59:59",
+				VestingWithdrawRate: "0.000000 VESTS",
+			},
+			want:    []h.PowerDownPayment{},
+			wantErr: false,
+		},
+		{
+			name: "Invalid rate",
+			acc: h.AccountData{
+				Name:                "jrswab",
+				VestingWithdrawRate: "many",
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.acc.PowerDownSchedule(routes)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("AccountData.PowerDownSchedule() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("AccountData.PowerDownSchedule() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAccountData_PowerDownScheduleLargeAmounts(t *testing.T) {
+	// fc encodes int64 values above INT32_MAX as strings.
+	data := `{
+		"name": "jrswab",
+		"next_vesting_withdraw": "2020-03-20T14:00:00",
+		"vesting_withdraw_rate": "2000000.000000 VESTS",
+		"to_withdraw": "26000000000000",
+		"withdrawn": 4000000000000
+	}`
+	var acc h.AccountData
+	if err := json.Unmarshal([]byte(data), &acc); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+
+	got, err := acc.PowerDownSchedule(nil)
+	if err != nil {
+		t.Fatalf("AccountData.PowerDownSchedule() error = %v", err)
+	}
+	if len(got) != 11 || got[10].Amount.Amount != 2000000000000 {
+		t.Errorf("AccountData.PowerDownSchedule() = %v payments, want 11 of 2000000.000000 VESTS", len(got))
+	}
+}
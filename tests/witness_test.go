@@ -0,0 +1,218 @@
+package gohive
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	h "github.com/nathansenn/go-hive"
+	"github.com/nathansenn/go-hive/mocks"
+	"github.com/stretchr/testify/mock"
+	rpc "github.com/ybbus/jsonrpc"
+)
+
+var witnessResult = map[string]interface{}{
+	"id":                       12,
+	"owner":                    "jrswab",
+	"created":                  "2020-03-20T14:00:00",
+	"url":                      "https://jrswab.com",
+	"votes":                    "123456789012345678",
+	"total_missed":             3,
+	"last_aslot":               4000,
+	"last_confirmed_block_num": 3990,
+	"signing_key":              "STM7Hqk4VQdKBsXPM4tkJ6oB1WdXxrKfLfCzzYAytNMEtZHbCFgNq",
+	"props": map[string]interface{}{
+		"account_creation_fee":   "3.000 HIVE",
+		"maximum_block_size":     65536,
+		"hbd_interest_rate":      2000,
+		"account_subsidy_budget": 797,
+		"account_subsidy_decay":  347321,
+	},
+	"hbd_exchange_rate":        map[string]interface{}{"base": "0.250 HBD", "quote": "1.000 HIVE"},
+	"last_hbd_exchange_update": "2020-03-21T14:00:00",
+	"running_version":          "1.25.0",
+	"hardfork_version_vote":    "1.25.0",
+	"hardfork_time_vote":       "2021-06-30T14:00:00",
+}
+
+var witnessWant = h.Witness{
+	ID:                    12,
+	Owner:                 "jrswab",
+	Created:               h.Time{Time: time.Date(2020, 3, 20, 14, 0, 0, 0, time.UTC)},
+	URL:                   "https://jrswab.com",
+	Votes:                 "123456789012345678",
+	TotalMissed:           3,
+	LastAslot:             4000,
+	LastConfirmedBlockNum: 3990,
+	SigningKey:            "STM7Hqk4VQdKBsXPM4tkJ6oB1WdXxrKfLfCzzYAytNMEtZHbCFgNq",
+	Props: h.ChainProperties{
+		AccountCreationFee:   h.NewAsset(3, h.HIVE),
+		MaximumBlockSize:     65536,
+		HbdInterestRate:      2000,
+		AccountSubsidyBudget: 797,
+		AccountSubsidyDecay:  347321,
+	},
+	HbdExchangeRate:       h.Price{Base: h.NewAsset(0.25, h.HBD), Quote: h.NewAsset(1, h.HIVE)},
+	LastHbdExchangeUpdate: h.Time{Time: time.Date(2020, 3, 21, 14, 0, 0, 0, time.UTC)},
+	RunningVersion:        "1.25.0",
+	HardforkVersionVote:   "1.25.0",
+	HardforkTimeVote:      h.Time{Time: time.Date(2021, 6, 30, 14, 0, 0, 0, time.UTC)},
+}
+
+func TestChain_GetWitnessByAccount(t *testing.T) {
+	mockCall := new(mocks.Caller)
+	output := &rpc.RPCResponse{
+		JSONRPC: "2.0",
+		Result:  witnessResult,
+		ID:      0,
+	}
+	output2 := &rpc.RPCResponse{
+		JSONRPC: "2.0",
+		Result:  nil,
+		ID:      0,
+	}
+	mockCall.On("CallRaw", mock.Anything).Return(output, nil).Once()
+	mockCall.On("CallRaw", mock.Anything).Return(nil, fmt.Errorf("fake error message")).Once()
+	mockCall.On("CallRaw", mock.Anything).Return(output2, nil).Once()
+
+	tests := []struct {
+		name    string
+		want    *h.Witness
+		wantErr bool
+	}{
+		{
+			name:    "Get witness",
+			want:    &witnessWant,
+			wantErr: false,
+		},
+		{
+			name:    "Get call error message",
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Not a witness",
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &h.Client{
+				URL:    "https://api.hive.blog",
+				Client: mockCall,
+			}
+			got, err := c.GetWitnessByAccount("jrswab")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Chain.GetWitnessByAccount() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Chain.GetWitnessByAccount() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChain_GetWitnessesByVote(t *testing.T) {
+	mockCall := new(mocks.Caller)
+	output := &rpc.RPCResponse{
+		JSONRPC: "2.0",
+		Result:  []interface{}{witnessResult},
+		ID:      0,
+	}
+	mockCall.On("CallRaw", mock.Anything).Return(output, nil).Once()
+
+	tests := []struct {
+		name    string
+		limit   int
+		want    []h.Witness
+		wantErr bool
+	}{
+		{
+			name:    "Get witnesses",
+			limit:   1,
+			want:    []h.Witness{witnessWant},
+			wantErr: false,
+		},
+		{
+			name:    "Limit too large",
+			limit:   101,
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &h.Client{
+				URL:    "https://api.hive.blog",
+				Client: mockCall,
+			}
+			got, err := c.GetWitnessesByVote("", tt.limit)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Chain.GetWitnessesByVote() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Chain.GetWitnessesByVote() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChain_GetFeedHistory(t *testing.T) {
+	price := map[string]interface{}{"base": "0.250 HBD", "quote": "1.000 HIVE"}
+	mockCall := new(mocks.Caller)
+	output := &rpc.RPCResponse{
+		JSONRPC: "2.0",
+		Result: map[string]interface{}{
+			"id":                     0,
+			"current_median_history": price,
+			"market_median_history":  price,
+			"current_min_history":    price,
+			"current_max_history":    price,
+			"price_history":          []interface{}{price, price},
+		},
+		ID: 0,
+	}
+	mockCall.On("CallRaw", emptyParams).Return(output, nil).Once()
+
+	c := &h.Client{
+		URL:    "https://api.hive.blog",
+		Client: mockCall,
+	}
+	got, err := c.GetFeedHistory()
+	if err != nil {
+		t.Fatalf("Chain.GetFeedHistory() error = %v", err)
+	}
+	want := h.Price{Base: h.NewAsset(0.25, h.HBD), Quote: h.NewAsset(1, h.HIVE)}
+	if !reflect.DeepEqual(got.CurrentMedianHistory, want) {
+		t.Errorf("Chain.GetFeedHistory() median = %v, want %v", got.CurrentMedianHistory, want)
+	}
+	if len(got.PriceHistory) != 2 {
+		t.Errorf("Chain.GetFeedHistory() returned %d prices, want 2", len(got.PriceHistory))
+	}
+}
+
+func TestChain_GetActiveWitnesses(t *testing.T) {
+	mockCall := new(mocks.Caller)
+	output := &rpc.RPCResponse{
+		JSONRPC: "2.0",
+		Result:  []interface{}{"blocktrades", "gtg"},
+		ID:      0,
+	}
+	mockCall.On("CallRaw", emptyParams).Return(output, nil).Once()
+
+	c := &h.Client{
+		URL:    "https://api.hive.blog",
+		Client: mockCall,
+	}
+	got, err := c.GetActiveWitnesses()
+	if err != nil {
+		t.Fatalf("Chain.GetActiveWitnesses() error = %v", err)
+	}
+	if want := []string{"blocktrades", "gtg"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Chain.GetActiveWitnesses() = %v, want %v", got, want)
+	}
+}
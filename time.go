@@ -0,0 +1,57 @@
+package gohive
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// TimeLayout is the layout used by Hive nodes for timestamps. All times are UTC.
+const TimeLayout = "2006-01-02T15:04:05"
+
+// hivemindTimeLayout is the layout of some timestamps returned by the bridge API.
+const hivemindTimeLayout = "2006-01-02 15:04:05"
+
+// Time is a time.Time encoded in the Hive timestamp layout.
+type Time struct {
+	time.Time
+}
+
+// String returns the time in the Hive timestamp layout.
+func (t Time) String() string {
+	return t.UTC().Format(TimeLayout)
+}
+
+// MarshalJSON encodes the time in the Hive timestamp layout.
+func (t Time) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
+
+// UnmarshalJSON decodes a Hive timestamp.
+func (t *Time) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	parsed, err := parseAnyTime(s)
+	if err != nil {
+		return err
+	}
+	t.Time = parsed
+	return nil
+}
+
+// parseTime converts a Hive timestamp into a time.Time.
+func parseTime(s string) (time.Time, error) {
+	return time.ParseInLocation(TimeLayout, s, time.UTC)
+}
+
+// parseAnyTime converts a timestamp in TimeLayout or the bridge's space separated layout.
+func parseAnyTime(s string) (time.Time, error) {
+	t, err := parseTime(s)
+	if err != nil {
+		if t, err2 := time.ParseInLocation(hivemindTimeLayout, s, time.UTC); err2 == nil {
+			return t, nil
+		}
+	}
+	return t, err
+}
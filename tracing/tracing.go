@@ -0,0 +1,91 @@
+// Package tracing creates OpenTelemetry spans for calls made through a gohive.Client.
+// Spans are children of the context passed to Client.WithContext.
+// Example:
+// c := gohive.NewClient()
+// tracing.Instrument(c, otel.Tracer("hive"))
+//
+// accs, err := c.WithContext(ctx).GetAccounts("jrswab")
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+
+	gohive "github.com/nathansenn/go-hive"
+	rpc "github.com/ybbus/jsonrpc"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Span attribute keys set on every call.
+const (
+	AttrMethod       = attribute.Key("rpc.method")
+	AttrSystem       = attribute.Key("rpc.system")
+	AttrNode         = attribute.Key("hive.node")
+	AttrParamsSize   = attribute.Key("rpc.request.params_size")
+	AttrRequestID    = attribute.Key("rpc.jsonrpc.request_id")
+	AttrErrorCode    = attribute.Key("rpc.jsonrpc.error_code")
+	AttrErrorMessage = attribute.Key("rpc.jsonrpc.error_message")
+)
+
+// Caller is a gohive.Caller which wraps every request in a client span.
+type Caller struct {
+	Next   gohive.Caller
+	Node   string
+	Tracer trace.Tracer
+}
+
+// CallRaw starts a root span for req. Use Client.WithContext to attach it to a trace.
+func (c *Caller) CallRaw(req *rpc.RPCRequest) (*rpc.RPCResponse, error) {
+	return c.CallRawContext(context.Background(), req)
+}
+
+// CallRawContext starts a span for req as a child of ctx and passes req on to Next.
+func (c *Caller) CallRawContext(ctx context.Context, req *rpc.RPCRequest) (*rpc.RPCResponse, error) {
+	ctx, span := c.Tracer.Start(ctx, req.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			AttrSystem.String("jsonrpc"),
+			AttrMethod.String(req.Method),
+			AttrNode.String(c.Node),
+			AttrParamsSize.Int(paramsSize(req)),
+			AttrRequestID.Int(req.ID),
+		),
+	)
+	defer span.End()
+
+	resp, err := gohive.CallContext(ctx, c.Next, req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return resp, err
+	}
+
+	if resp != nil && resp.Error != nil {
+		span.SetAttributes(
+			AttrErrorCode.Int(resp.Error.Code),
+			AttrErrorMessage.String(resp.Error.Message),
+		)
+		span.SetStatus(codes.Error, resp.Error.Message)
+	}
+	return resp, nil
+}
+
+// Instrument wraps the Caller of c so every call through c creates a span from tracer.
+func Instrument(c *gohive.Client, tracer trace.Tracer) {
+	c.Client = &Caller{
+		Next:   c.Client,
+		Node:   c.URL,
+		Tracer: tracer,
+	}
+}
+
+// paramsSize returns the size in bytes of the encoded params of req.
+func paramsSize(req *rpc.RPCRequest) int {
+	b, err := json.Marshal(req.Params)
+	if err != nil {
+		return 0
+	}
+	return len(b)
+}
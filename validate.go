@@ -0,0 +1,149 @@
+package gohive
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Limits enforced by hived when validating operations.
+const (
+	maxPermlinkLength    = 256
+	maxMemoLength        = 2048
+	maxTitleLength       = 256
+	maxCustomOpIDLength  = 32
+	maxBeneficiaries     = 128
+	maxProposalIDs       = 5
+	maxProposalSubject   = 80
+	minRecurrenceHours   = 24
+	minRecurrentPayments = 2
+)
+
+// assetPrecisions holds the precision hived requires for each symbol.
+var assetPrecisions = map[string]uint8{
+	HIVE:  3,
+	HBD:   3,
+	VESTS: 6,
+}
+
+// ValidateAccountName checks name follows the Hive account name rules:
+// 3 to 16 characters in dot separated parts of at least 3 characters, each
+// starting with a letter, ending with a letter or digit and holding only
+// lowercase letters, digits and hyphens.
+func ValidateAccountName(name string) error {
+	if len(name) < 3 || len(name) > 16 {
+		return fmt.Errorf("account name %q must be between 3 and 16 characters", name)
+	}
+	for _, part := range strings.Split(name, ".") {
+		if len(part) < 3 {
+			return fmt.Errorf("account name %q has a part shorter than 3 characters", name)
+		}
+		if part[0] < 'a' || part[0] > 'z' {
+			return fmt.Errorf("account name %q has a part not starting with a letter", name)
+		}
+		last := part[len(part)-1]
+		if !(last >= 'a' && last <= 'z' || last >= '0' && last <= '9') {
+			return fmt.Errorf("account name %q has a part not ending with a letter or digit", name)
+		}
+		for _, r := range part {
+			if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-') {
+				return fmt.Errorf("account name %q contains %q", name, r)
+			}
+		}
+	}
+	return nil
+}
+
+// ValidatePermlink checks permlink is accepted by hived: not empty, shorter than 256 bytes and valid UTF-8.
+func ValidatePermlink(permlink string) error {
+	if permlink == "" {
+		return fmt.Errorf("permlink is empty")
+	}
+	if len(permlink) >= maxPermlinkLength {
+		return fmt.Errorf("permlink %q is too long", permlink)
+	}
+	if !utf8.ValidString(permlink) {
+		return fmt.Errorf("permlink %q is not valid UTF-8", permlink)
+	}
+	return nil
+}
+
+// validateAccounts checks every name with ValidateAccountName.
+func validateAccounts(names ...string) error {
+	for _, name := range names {
+		if err := ValidateAccountName(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateAsset checks a has one of symbols, with its precision, and is not
+// negative, or positive when positive is set.
+func validateAsset(field string, a Asset, positive bool, symbols ...string) error {
+	known := false
+	for _, s := range symbols {
+		if a.Symbol == s {
+			known = true
+		}
+	}
+	if !known {
+		return fmt.Errorf("%s must be in %s, got %q", field, strings.Join(symbols, " or "), a.Symbol)
+	}
+	if a.Precision != assetPrecisions[a.Symbol] {
+		return fmt.Errorf("%s must have a precision of %d", field, assetPrecisions[a.Symbol])
+	}
+	if a.Amount < 0 || positive && a.Amount == 0 {
+		return fmt.Errorf("%s must be positive, got %s", field, a)
+	}
+	return nil
+}
+
+// validateMemo checks a memo fits in a transfer.
+func validateMemo(memo string) error {
+	if len(memo) >= maxMemoLength {
+		return fmt.Errorf("memo must be shorter than %d bytes", maxMemoLength)
+	}
+	if !utf8.ValidString(memo) {
+		return fmt.Errorf("memo is not valid UTF-8")
+	}
+	return nil
+}
+
+// validatePrice checks p is a positive price between HIVE and HBD.
+func validatePrice(field string, p Price) error {
+	if err := validateAsset(field+" base", p.Base, true, HIVE, HBD); err != nil {
+		return err
+	}
+	if err := validateAsset(field+" quote", p.Quote, true, HIVE, HBD); err != nil {
+		return err
+	}
+	if p.Base.Symbol == p.Quote.Symbol {
+		return fmt.Errorf("%s must be a price between HIVE and HBD", field)
+	}
+	return nil
+}
+
+// validateProposalIDs checks ids holds 1 to 5 unique, not negative proposal ids.
+func validateProposalIDs(field string, ids []int64) error {
+	if len(ids) < 1 || len(ids) > maxProposalIDs {
+		return fmt.Errorf("%s must have between 1 and %d proposal ids", field, maxProposalIDs)
+	}
+	seen := map[int64]bool{}
+	for _, id := range ids {
+		if id < 0 || seen[id] {
+			return fmt.Errorf("proposal ids must be unique and not negative")
+		}
+		seen[id] = true
+	}
+	return nil
+}
+
+// validateJSON checks s is empty or valid JSON.
+func validateJSON(field, s string) error {
+	if s != "" && !json.Valid([]byte(s)) {
+		return fmt.Errorf("%s is not valid JSON", field)
+	}
+	return nil
+}
@@ -0,0 +1,208 @@
+package gohive
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Names of the virtual operations UnmarshalVirtualOperation decodes.
+const (
+	FillConvertRequestOp      OpType = "fill_convert_request"
+	AuthorRewardOp            OpType = "author_reward"
+	CurationRewardOp          OpType = "curation_reward"
+	InterestOp                OpType = "interest"
+	FillVestingWithdrawOp     OpType = "fill_vesting_withdraw"
+	FillOrderOp               OpType = "fill_order"
+	FillTransferFromSavingsOp OpType = "fill_transfer_from_savings"
+	ReturnVestingDelegationOp OpType = "return_vesting_delegation"
+	CommentBenefactorRewardOp OpType = "comment_benefactor_reward"
+	ProducerRewardOp          OpType = "producer_reward"
+	ProposalPayOp             OpType = "proposal_pay"
+	FillRecurrentTransferOp   OpType = "fill_recurrent_transfer"
+)
+
+// VirtualOperation is an operation the chain produces itself, such as a reward
+// payout. Virtual operations show up in account history but cannot be broadcast.
+type VirtualOperation interface {
+	// Type returns the name of the operation.
+	Type() OpType
+	virtual()
+}
+
+// Type returns CurationRewardOp.
+func (op CurationRewardOperation) Type() OpType { return CurationRewardOp }
+func (CurationRewardOperation) virtual()        {}
+
+// FillConvertRequestOperation settles a conversion request.
+type FillConvertRequestOperation struct {
+	Owner     string `json:"owner"`
+	RequestID uint32 `json:"requestid"`
+	AmountIn  Asset  `json:"amount_in"`
+	AmountOut Asset  `json:"amount_out"`
+}
+
+// Type returns FillConvertRequestOp.
+func (op FillConvertRequestOperation) Type() OpType { return FillConvertRequestOp }
+func (FillConvertRequestOperation) virtual()        {}
+
+// AuthorRewardOperation pays the author of a post at payout.
+type AuthorRewardOperation struct {
+	Author                string `json:"author"`
+	Permlink              string `json:"permlink"`
+	HbdPayout             Asset  `json:"hbd_payout"`
+	HivePayout            Asset  `json:"hive_payout"`
+	VestingPayout         Asset  `json:"vesting_payout"`
+	CuratorsVestingPayout Asset  `json:"curators_vesting_payout"`
+	PayoutMustBeClaimed   bool   `json:"payout_must_be_claimed"`
+}
+
+// Type returns AuthorRewardOp.
+func (op AuthorRewardOperation) Type() OpType { return AuthorRewardOp }
+func (AuthorRewardOperation) virtual()        {}
+
+// CommentBenefactorRewardOperation pays a beneficiary of a post at payout.
+type CommentBenefactorRewardOperation struct {
+	Benefactor          string `json:"benefactor"`
+	Author              string `json:"author"`
+	Permlink            string `json:"permlink"`
+	HbdPayout           Asset  `json:"hbd_payout"`
+	HivePayout          Asset  `json:"hive_payout"`
+	VestingPayout       Asset  `json:"vesting_payout"`
+	PayoutMustBeClaimed bool   `json:"payout_must_be_claimed"`
+}
+
+// Type returns CommentBenefactorRewardOp.
+func (op CommentBenefactorRewardOperation) Type() OpType { return CommentBenefactorRewardOp }
+func (CommentBenefactorRewardOperation) virtual()        {}
+
+// InterestOperation pays HBD interest.
+type InterestOperation struct {
+	Owner    string `json:"owner"`
+	Interest Asset  `json:"interest"`
+}
+
+// Type returns InterestOp.
+func (op InterestOperation) Type() OpType { return InterestOp }
+func (InterestOperation) virtual()        {}
+
+// FillVestingWithdrawOperation is a weekly power down payment, sent from FromAccount to ToAccount.
+type FillVestingWithdrawOperation struct {
+	FromAccount string `json:"from_account"`
+	ToAccount   string `json:"to_account"`
+	Withdrawn   Asset  `json:"withdrawn"`
+	Deposited   Asset  `json:"deposited"`
+}
+
+// Type returns FillVestingWithdrawOp.
+func (op FillVestingWithdrawOperation) Type() OpType { return FillVestingWithdrawOp }
+func (FillVestingWithdrawOperation) virtual()        {}
+
+// FillOrderOperation matches two orders of the internal market.
+type FillOrderOperation struct {
+	CurrentOwner   string `json:"current_owner"`
+	CurrentOrderID uint32 `json:"current_orderid"`
+	CurrentPays    Asset  `json:"current_pays"`
+	OpenOwner      string `json:"open_owner"`
+	OpenOrderID    uint32 `json:"open_orderid"`
+	OpenPays       Asset  `json:"open_pays"`
+}
+
+// Type returns FillOrderOp.
+func (op FillOrderOperation) Type() OpType { return FillOrderOp }
+func (FillOrderOperation) virtual()        {}
+
+// FillTransferFromSavingsOperation completes a withdrawal from savings.
+type FillTransferFromSavingsOperation struct {
+	From      string `json:"from"`
+	To        string `json:"to"`
+	Amount    Asset  `json:"amount"`
+	RequestID uint32 `json:"request_id"`
+	Memo      string `json:"memo"`
+}
+
+// Type returns FillTransferFromSavingsOp.
+func (op FillTransferFromSavingsOperation) Type() OpType { return FillTransferFromSavingsOp }
+func (FillTransferFromSavingsOperation) virtual()        {}
+
+// ReturnVestingDelegationOperation returns removed delegated VESTS to the delegator.
+type ReturnVestingDelegationOperation struct {
+	Account       string `json:"account"`
+	VestingShares Asset  `json:"vesting_shares"`
+}
+
+// Type returns ReturnVestingDelegationOp.
+func (op ReturnVestingDelegationOperation) Type() OpType { return ReturnVestingDelegationOp }
+func (ReturnVestingDelegationOperation) virtual()        {}
+
+// ProducerRewardOperation pays a witness for a block.
+type ProducerRewardOperation struct {
+	Producer      string `json:"producer"`
+	VestingShares Asset  `json:"vesting_shares"`
+}
+
+// Type returns ProducerRewardOp.
+func (op ProducerRewardOperation) Type() OpType { return ProducerRewardOp }
+func (ProducerRewardOperation) virtual()        {}
+
+// ProposalPayOperation pays a DHF proposal.
+type ProposalPayOperation struct {
+	Receiver string `json:"receiver"`
+	Payer    string `json:"payer"`
+	Payment  Asset  `json:"payment"`
+}
+
+// Type returns ProposalPayOp.
+func (op ProposalPayOperation) Type() OpType { return ProposalPayOp }
+func (ProposalPayOperation) virtual()        {}
+
+// FillRecurrentTransferOperation is one payment of a recurrent transfer.
+type FillRecurrentTransferOperation struct {
+	From                string `json:"from"`
+	To                  string `json:"to"`
+	Amount              Asset  `json:"amount"`
+	Memo                string `json:"memo"`
+	RemainingExecutions uint16 `json:"remaining_executions"`
+}
+
+// Type returns FillRecurrentTransferOp.
+func (op FillRecurrentTransferOperation) Type() OpType { return FillRecurrentTransferOp }
+func (FillRecurrentTransferOperation) virtual()        {}
+
+// virtualOperationTypes creates an empty operation for each name UnmarshalVirtualOperation decodes.
+var virtualOperationTypes = map[OpType]func() VirtualOperation{
+	FillConvertRequestOp:      func() VirtualOperation { return &FillConvertRequestOperation{} },
+	AuthorRewardOp:            func() VirtualOperation { return &AuthorRewardOperation{} },
+	CurationRewardOp:          func() VirtualOperation { return &CurationRewardOperation{} },
+	InterestOp:                func() VirtualOperation { return &InterestOperation{} },
+	FillVestingWithdrawOp:     func() VirtualOperation { return &FillVestingWithdrawOperation{} },
+	FillOrderOp:               func() VirtualOperation { return &FillOrderOperation{} },
+	FillTransferFromSavingsOp: func() VirtualOperation { return &FillTransferFromSavingsOperation{} },
+	ReturnVestingDelegationOp: func() VirtualOperation { return &ReturnVestingDelegationOperation{} },
+	CommentBenefactorRewardOp: func() VirtualOperation { return &CommentBenefactorRewardOperation{} },
+	ProducerRewardOp:          func() VirtualOperation { return &ProducerRewardOperation{} },
+	ProposalPayOp:             func() VirtualOperation { return &ProposalPayOperation{} },
+	FillRecurrentTransferOp:   func() VirtualOperation { return &FillRecurrentTransferOperation{} },
+}
+
+// UnmarshalVirtualOperation decodes a virtual operation in the condenser form,
+// ["curation_reward", {...}], into a pointer to its type, such as *CurationRewardOperation.
+func UnmarshalVirtualOperation(data []byte) (VirtualOperation, error) {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil || len(raw) != 2 {
+		return nil, fmt.Errorf("invalid operation %s", data)
+	}
+	var name OpType
+	if err := json.Unmarshal(raw[0], &name); err != nil {
+		return nil, fmt.Errorf("invalid operation name %s", raw[0])
+	}
+	newOp, ok := virtualOperationTypes[name]
+	if !ok {
+		return nil, fmt.Errorf("unsupported virtual operation %q", name)
+	}
+
+	op := newOp()
+	if err := json.Unmarshal(raw[1], op); err != nil {
+		return nil, fmt.Errorf("invalid %s operation: %v", name, err)
+	}
+	return op, nil
+}
@@ -0,0 +1,304 @@
+package gohive
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	rpc "github.com/ybbus/jsonrpc"
+)
+
+// errConnClosed is returned for calls still waiting when a WebSocket connection drops.
+var errConnClosed = errors.New("websocket connection closed")
+
+// errCallerClosed is returned for calls made after WSCaller.Close.
+var errCallerClosed = errors.New("websocket caller is closed")
+
+// isWebSocketURL reports whether URL should be served by a WSCaller.
+func isWebSocketURL(URL string) bool {
+	return strings.HasPrefix(URL, "ws://") || strings.HasPrefix(URL, "wss://")
+}
+
+// WSCaller is a Caller which sends JSON-RPC requests over a persistent WebSocket connection.
+// Responses are matched to requests by id, so it is safe for concurrent use.
+// The connection is opened on the first call and reopened by the next call after it drops.
+// When URL cannot be dialed the call moves on to the next of Fallbacks, and stays there.
+type WSCaller struct {
+	URL       string
+	Fallbacks []string
+	Dialer    *websocket.Dialer
+	Timeout   time.Duration
+
+	// OnRetry, when set, is called before a request is sent again to node.
+	OnRetry func(method, node string)
+	// OnFailover, when set, is called when a request moves from one node to another.
+	OnFailover func(method, from, to string)
+
+	mu     sync.Mutex
+	conn   *wsConn
+	node   int
+	nextID int
+	closed bool
+}
+
+// NewWSCaller creates a WSCaller for a ws:// or wss:// URL.
+// Calls without a context deadline time out after 30 seconds.
+func NewWSCaller(URL string) *WSCaller {
+	return &WSCaller{
+		URL:     URL,
+		Dialer:  websocket.DefaultDialer,
+		Timeout: 30 * time.Second,
+	}
+}
+
+// CallRaw sends req and waits for the response with the same id.
+func (w *WSCaller) CallRaw(req *rpc.RPCRequest) (*rpc.RPCResponse, error) {
+	return w.CallRawContext(context.Background(), req)
+}
+
+// CallRawContext sends req and waits for its response until ctx is done.
+// A request which could not be written is sent once more on a new connection,
+// and one whose node could not be dialed is sent to the next fallback node.
+func (w *WSCaller) CallRawContext(ctx context.Context, req *rpc.RPCRequest) (*rpc.RPCResponse, error) {
+	if _, ok := ctx.Deadline(); !ok && w.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, w.Timeout)
+		defer cancel()
+	}
+
+	var err error
+	for attempt := 0; attempt < 2+len(w.Fallbacks); attempt++ {
+		if attempt > 0 && w.OnRetry != nil {
+			w.OnRetry(req.Method, w.currentURL())
+		}
+
+		var conn *wsConn
+		conn, err = w.connect(ctx)
+		if err != nil {
+			if errors.Is(err, errCallerClosed) || !w.failover(req.Method) {
+				return nil, err
+			}
+			continue
+		}
+
+		var resp *rpc.RPCResponse
+		var sent bool
+		resp, sent, err = conn.call(ctx, w.id(), req)
+		if err == nil {
+			resp.ID = req.ID
+			return resp, nil
+		}
+		if sent || attempt > 0 {
+			break
+		}
+		w.drop(conn)
+	}
+	return nil, fmt.Errorf("rpc call %v() on %v: %v", req.Method, w.currentURL(), err)
+}
+
+// currentURL returns the URL of the node calls are sent to.
+func (w *WSCaller) currentURL() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.nodeURL(w.node)
+}
+
+// nodeURL returns URL for 0 and the fallbacks after it. w.mu must be held.
+func (w *WSCaller) nodeURL(i int) string {
+	if i == 0 {
+		return w.URL
+	}
+	return w.Fallbacks[i-1]
+}
+
+// failover moves calls to the next node and reports whether there was one.
+// Every node is tried once per call, so it stops after the last fallback.
+func (w *WSCaller) failover(method string) bool {
+	w.mu.Lock()
+	if w.node >= len(w.Fallbacks) {
+		w.mu.Unlock()
+		return false
+	}
+	from := w.nodeURL(w.node)
+	w.node++
+	to := w.nodeURL(w.node)
+	w.mu.Unlock()
+
+	if w.OnFailover != nil {
+		w.OnFailover(method, from, to)
+	}
+	return true
+}
+
+// Close closes the connection. Calls made after Close fail.
+func (w *WSCaller) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.closed = true
+	if w.conn == nil {
+		return nil
+	}
+	err := w.conn.ws.Close()
+	w.conn = nil
+	return err
+}
+
+// id returns the next JSON-RPC id.
+func (w *WSCaller) id() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.nextID++
+	return w.nextID
+}
+
+// connect returns the open connection, dialing a new one when there is none.
+// The dial happens without holding the lock, so a slow node does not block Close;
+// when concurrent calls both dial, the first connection stored wins.
+func (w *WSCaller) connect(ctx context.Context) (*wsConn, error) {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return nil, fmt.Errorf("%w: %v", errCallerClosed, w.URL)
+	}
+	if w.conn != nil {
+		conn := w.conn
+		w.mu.Unlock()
+		return conn, nil
+	}
+	url := w.nodeURL(w.node)
+	w.mu.Unlock()
+
+	ws, _, err := w.Dialer.DialContext(ctx, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("websocket dial %v: %v", url, err)
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		ws.Close()
+		return nil, fmt.Errorf("%w: %v", errCallerClosed, w.URL)
+	}
+	if w.conn != nil {
+		ws.Close()
+		return w.conn, nil
+	}
+
+	conn := &wsConn{ws: ws, pending: make(map[int]chan *rpc.RPCResponse)}
+	w.conn = conn
+	go w.readLoop(conn)
+	return conn, nil
+}
+
+// drop closes conn and forgets it if it is still the current connection.
+func (w *WSCaller) drop(conn *wsConn) {
+	w.mu.Lock()
+	if w.conn == conn {
+		w.conn = nil
+	}
+	w.mu.Unlock()
+	conn.ws.Close()
+}
+
+// readLoop delivers responses on conn until it fails.
+func (w *WSCaller) readLoop(conn *wsConn) {
+	for {
+		_, msg, err := conn.ws.ReadMessage()
+		if err != nil {
+			w.drop(conn)
+			conn.fail()
+			return
+		}
+
+		var resp *rpc.RPCResponse
+		dec := json.NewDecoder(bytes.NewReader(msg))
+		dec.UseNumber()
+		if err := dec.Decode(&resp); err != nil || resp == nil {
+			continue
+		}
+		conn.deliver(resp)
+	}
+}
+
+// wsConn is a single WebSocket connection and the calls waiting on it.
+type wsConn struct {
+	ws      *websocket.Conn
+	writeMu sync.Mutex
+
+	mu      sync.Mutex
+	pending map[int]chan *rpc.RPCResponse
+	failed  bool
+}
+
+// call writes req under id and waits for the response. sent reports whether
+// the request reached the connection.
+func (c *wsConn) call(ctx context.Context, id int, req *rpc.RPCRequest) (resp *rpc.RPCResponse, sent bool, err error) {
+	ch := make(chan *rpc.RPCResponse, 1)
+
+	c.mu.Lock()
+	if c.failed {
+		c.mu.Unlock()
+		return nil, false, errConnClosed
+	}
+	c.pending[id] = ch
+	c.mu.Unlock()
+	defer c.forget(id)
+
+	out := *req
+	out.ID = id
+	c.writeMu.Lock()
+	// The connection is shared, so the deadline of an earlier call must not carry
+	// over; a zero deadline clears it.
+	deadline, _ := ctx.Deadline()
+	c.ws.SetWriteDeadline(deadline)
+	err = c.ws.WriteJSON(&out)
+	c.writeMu.Unlock()
+	if err != nil {
+		return nil, false, err
+	}
+
+	select {
+	case resp, ok := <-ch:
+		if !ok {
+			return nil, true, errConnClosed
+		}
+		return resp, true, nil
+	case <-ctx.Done():
+		return nil, true, ctx.Err()
+	}
+}
+
+// deliver hands resp to the call waiting for its id.
+func (c *wsConn) deliver(resp *rpc.RPCResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if ch, ok := c.pending[resp.ID]; ok {
+		ch <- resp
+		delete(c.pending, resp.ID)
+	}
+}
+
+// forget removes the call waiting for id.
+func (c *wsConn) forget(id int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.pending, id)
+}
+
+// fail ends every waiting call with errConnClosed.
+func (c *wsConn) fail() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.failed = true
+	for id, ch := range c.pending {
+		close(ch)
+		delete(c.pending, id)
+	}
+}
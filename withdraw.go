@@ -0,0 +1,135 @@
+package gohive
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// WithdrawRouteType selects which withdraw routes GetWithdrawRoutes returns.
+type WithdrawRouteType string
+
+// Route types accepted by get_withdraw_routes.
+const (
+	WithdrawRoutesOutgoing WithdrawRouteType = "outgoing"
+	WithdrawRoutesIncoming WithdrawRouteType = "incoming"
+	WithdrawRoutesAll      WithdrawRouteType = "all"
+)
+
+// powerDownInterval is the time between two power down payments.
+const powerDownInterval = 7 * 24 * time.Hour
+
+// percent100 is 100% in the basis points used by withdraw routes.
+const percent100 = 10000
+
+// WithdrawRoute sends part of every power down payment of FromAccount to ToAccount.
+// Percent is in basis points, so 10000 is 100%.
+type WithdrawRoute struct {
+	ID          int64  `json:"id"`
+	FromAccount string `json:"from_account"`
+	ToAccount   string `json:"to_account"`
+	Percent     int    `json:"percent"`
+	AutoVest    bool   `json:"auto_vest"`
+}
+
+// GetWithdrawRoutes returns the withdraw routes of account.
+func (c *Client) GetWithdrawRoutes(account string, routeType WithdrawRouteType) ([]WithdrawRoute, error) {
+	resp, err := c.getAccountData("get_withdraw_routes", account, routeType)
+	if err != nil {
+		return nil, err
+	}
+
+	out := []WithdrawRoute{}
+	if err := resp.GetObject(&out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PowerDownRoute is the part of a power down payment sent along a withdraw route.
+type PowerDownRoute struct {
+	ToAccount string
+	Amount    Asset
+	AutoVest  bool
+}
+
+// PowerDownPayment is one weekly power down payment.
+// Amount is the total withdrawn that week; Routes lists the parts sent to
+// other accounts and Retained what is left for the account itself.
+type PowerDownPayment struct {
+	Date     time.Time
+	Amount   Asset
+	Routes   []PowerDownRoute
+	Retained Asset
+}
+
+// remainingWithdraw returns the VESTS left to withdraw in the current power down.
+func (a AccountData) remainingWithdraw() (int64, error) {
+	var amounts [2]int64
+	for i, n := range []json.Number{a.ToWithdraw, a.Withdrawn} {
+		if n == "" {
+			continue
+		}
+		v, err := n.Int64()
+		if err != nil {
+			return 0, fmt.Errorf("invalid power down amount %q: %v", n, err)
+		}
+		amounts[i] = v
+	}
+	return amounts[0] - amounts[1], nil
+}
+
+// PowerDownSchedule returns the remaining power down payments of the account,
+// split across the outgoing routes passed in. All amounts are in VESTS.
+// It returns an empty schedule when the account is not powering down.
+func (a AccountData) PowerDownSchedule(routes []WithdrawRoute) ([]PowerDownPayment, error) {
+	rate, err := ParseAsset(a.VestingWithdrawRate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid vesting withdraw rate: %v", err)
+	}
+	remaining, err := a.remainingWithdraw()
+	if err != nil {
+		return nil, err
+	}
+	out := []PowerDownPayment{}
+	if rate.Amount <= 0 || remaining <= 0 {
+		return out, nil
+	}
+
+	date, err := parseTime(a.NextVestingWithdraw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid next vesting withdraw: %v", err)
+	}
+
+	for remaining > 0 {
+		amount := rate.Amount
+		if remaining < amount {
+			amount = remaining
+		}
+
+		payment := PowerDownPayment{
+			Date:   date,
+			Amount: Asset{Amount: amount, Precision: rate.Precision, Symbol: rate.Symbol},
+			Routes: []PowerDownRoute{},
+		}
+		retained := amount
+		for _, r := range routes {
+			if r.FromAccount != a.Name {
+				continue
+			}
+			routed := amount * int64(r.Percent) / percent100
+			retained -= routed
+			payment.Routes = append(payment.Routes, PowerDownRoute{
+				ToAccount: r.ToAccount,
+				Amount:    Asset{Amount: routed, Precision: rate.Precision, Symbol: rate.Symbol},
+				AutoVest:  r.AutoVest,
+			})
+		}
+		payment.Retained = Asset{Amount: retained, Precision: rate.Precision, Symbol: rate.Symbol}
+
+		out = append(out, payment)
+		remaining -= amount
+		date = date.Add(powerDownInterval)
+	}
+	return out, nil
+}
@@ -0,0 +1,141 @@
+package gohive
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// maxWitnessLimit is the largest page get_witnesses_by_vote returns.
+const maxWitnessLimit = 100
+
+// ChainProperties are the chain parameters a witness votes for.
+type ChainProperties struct {
+	AccountCreationFee   Asset `json:"account_creation_fee"`
+	MaximumBlockSize     int64 `json:"maximum_block_size"`
+	HbdInterestRate      int   `json:"hbd_interest_rate"`
+	AccountSubsidyBudget int64 `json:"account_subsidy_budget"`
+	AccountSubsidyDecay  int64 `json:"account_subsidy_decay"`
+}
+
+// Witness is a block producer and the values it publishes.
+type Witness struct {
+	ID                    int64           `json:"id"`
+	Owner                 string          `json:"owner"`
+	Created               Time            `json:"created"`
+	URL                   string          `json:"url"`
+	Votes                 json.Number     `json:"votes"`
+	TotalMissed           int64           `json:"total_missed"`
+	LastAslot             int64           `json:"last_aslot"`
+	LastConfirmedBlockNum int64           `json:"last_confirmed_block_num"`
+	SigningKey            string          `json:"signing_key"`
+	Props                 ChainProperties `json:"props"`
+	HbdExchangeRate       Price           `json:"hbd_exchange_rate"`
+	LastHbdExchangeUpdate Time            `json:"last_hbd_exchange_update"`
+	RunningVersion        string          `json:"running_version"`
+	HardforkVersionVote   string          `json:"hardfork_version_vote"`
+	HardforkTimeVote      Time            `json:"hardfork_time_vote"`
+}
+
+// WitnessSchedule is the current witness shuffle and the median chain properties.
+type WitnessSchedule struct {
+	ID                        int64           `json:"id"`
+	CurrentVirtualTime        json.Number     `json:"current_virtual_time"`
+	NextShuffleBlockNum       int64           `json:"next_shuffle_block_num"`
+	CurrentShuffledWitnesses  []string        `json:"current_shuffled_witnesses"`
+	NumScheduledWitnesses     int             `json:"num_scheduled_witnesses"`
+	MedianProps               ChainProperties `json:"median_props"`
+	MajorityVersion           string          `json:"majority_version"`
+	MaxVotedWitnesses         int             `json:"max_voted_witnesses"`
+	MaxRunnerWitnesses        int             `json:"max_runner_witnesses"`
+	HardforkRequiredWitnesses int             `json:"hardfork_required_witnesses"`
+}
+
+// FeedHistory holds the HBD/HIVE prices published by witnesses over the last 3.5 days.
+type FeedHistory struct {
+	ID                   int64   `json:"id"`
+	CurrentMedianHistory Price   `json:"current_median_history"`
+	MarketMedianHistory  Price   `json:"market_median_history"`
+	CurrentMinHistory    Price   `json:"current_min_history"`
+	CurrentMaxHistory    Price   `json:"current_max_history"`
+	PriceHistory         []Price `json:"price_history"`
+}
+
+// GetWitnessByAccount returns the witness owned by account.
+// It returns an error when the account is not a witness.
+func (c *Client) GetWitnessByAccount(account string) (*Witness, error) {
+	resp, err := c.getAccountData("get_witness_by_account", account)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Result == nil {
+		return nil, fmt.Errorf("account %s is not a witness", account)
+	}
+
+	var out Witness
+	if err := resp.GetObject(&out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetWitnessesByVote returns up to limit witnesses ordered by votes, starting at the witness from.
+// An empty from begins with the witness with the most votes.
+func (c *Client) GetWitnessesByVote(from string, limit int) ([]Witness, error) {
+	if limit < 1 || limit > maxWitnessLimit {
+		return nil, fmt.Errorf("method GetWitnessesByVote needs a limit between 1 and %d", maxWitnessLimit)
+	}
+
+	resp, err := c.getAccountData("get_witnesses_by_vote", from, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	out := []Witness{}
+	if err := resp.GetObject(&out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetActiveWitnesses returns the names of the witnesses in the current schedule.
+func (c *Client) GetActiveWitnesses() ([]string, error) {
+	resp, err := c.getAccountData("get_active_witnesses")
+	if err != nil {
+		return nil, err
+	}
+
+	out := []string{}
+	if err := resp.GetObject(&out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetWitnessSchedule returns the current witness schedule.
+func (c *Client) GetWitnessSchedule() (*WitnessSchedule, error) {
+	resp, err := c.getAccountData("get_witness_schedule")
+	if err != nil {
+		return nil, err
+	}
+
+	var out WitnessSchedule
+	if err := resp.GetObject(&out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetFeedHistory returns the price feed history. Its CurrentMedianHistory is
+// the price returned by GetCurrentMedianHistoryPrice.
+func (c *Client) GetFeedHistory() (*FeedHistory, error) {
+	resp, err := c.getAccountData("get_feed_history")
+	if err != nil {
+		return nil, err
+	}
+
+	out := FeedHistory{PriceHistory: []Price{}}
+	if err := resp.GetObject(&out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}